@@ -1,33 +1,93 @@
 package api
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"server/solver"
+	"server/utils"
 	"strconv"
+	"time"
 
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
 )
 
+// The names of the topologies accepted in the requests
+var topologies = map[string]solver.Topology{
+	"planar":   solver.PlanarTopology,
+	"toroidal": solver.ToroidalTopology,
+}
+
+// The names of the grids accepted in the requests
+var grids = map[string]solver.Grid{
+	"square":    solver.SquareGrid,
+	"hexagonal": solver.HexagonalGrid,
+}
+
+// The names of the neighbourhood presets accepted in the requests
+var neighbourhoods = map[string]solver.NeighbourhoodPreset{
+	"vonNeumann": solver.VonNeumannNeighbourhood,
+	"moore":      solver.MooreNeighbourhood,
+	"x":          solver.XNeighbourhood,
+	"knight":     solver.KnightNeighbourhood,
+	"cross":      solver.CrossNeighbourhood,
+}
+
+// The names of the tie-breaking rules accepted in the requests
+var tieBreakingRules = map[string]solver.TieBreaking{
+	"lexicographic": solver.LexicographicTieBreaking,
+	"fewestRows":    solver.FewestRowsTieBreaking,
+	"centre":        solver.CentreTieBreaking,
+}
+
 type Api interface {
 	SetupHttpHandler() http.Handler
 }
 
+// The number of solutions listed on a page if not given, and the most that can be asked for
+const (
+	defaultPageSize = 10
+	maxPageSize     = 100
+)
+
+// The longest the solution of a request is optimized, after which the best one found so far is returned.
+// It is well below the write timeout of the server, so there is still time to write the response.
+const solveTimeout = 10 * time.Second
+
 type api struct {
-	solver solver.BoardSolver
+	solver        solver.BoardSolver
+	modularSolver solver.ModularSolver
+	graphSolver   solver.GraphSolver
+	enumerator    solver.SolutionEnumerator
+	corrector     solver.BoardCorrector
+}
+
+// The part of the solutions to list
+type page struct {
+	offset int
+	limit  int
+	// Whether the solutions are ordered by the number of clicks, instead of the enumeration order
+	sorted bool
 }
 
-func New(solver solver.BoardSolver) Api {
-	return &api{solver: solver}
+func New(solver solver.BoardSolver, modularSolver solver.ModularSolver, graphSolver solver.GraphSolver, enumerator solver.SolutionEnumerator, corrector solver.BoardCorrector) Api {
+	return &api{solver: solver, modularSolver: modularSolver, graphSolver: graphSolver, enumerator: enumerator, corrector: corrector}
 }
 
 func (api *api) SetupHttpHandler() http.Handler {
 	router := mux.NewRouter()
 	router.HandleFunc("/api/solutions/{board:[0-9a-v]{1,5}}", api.solutionHandler).Methods("GET")
+	router.HandleFunc("/api/solutions", api.puzzleSolutionHandler).Methods("POST")
+	router.HandleFunc("/api/modular-solutions", api.modularSolutionHandler).Methods("POST")
+	router.HandleFunc("/api/graph-solutions", api.graphSolutionHandler).Methods("POST")
+	router.HandleFunc("/api/all-solutions", api.allSolutionsHandler).Methods("POST")
+	router.HandleFunc("/api/optimal-solutions", api.optimalSolutionsHandler).Methods("POST")
+	router.HandleFunc("/api/quiet-patterns", api.quietPatternsHandler).Methods("POST")
+	router.HandleFunc("/api/nearest-solvable-board", api.nearestSolvableBoardHandler).Methods("POST")
 
 	loggedRouter := handlers.LoggingHandler(os.Stdout, router)
 	allowedOrigin := os.Getenv("FRONTEND_URL")
@@ -36,8 +96,9 @@ func (api *api) SetupHttpHandler() http.Handler {
 	}
 
 	corsAllowedOrigins := handlers.AllowedOrigins([]string{allowedOrigin})
-	corsAllowedMethods := handlers.AllowedMethods([]string{"GET"})
-	return handlers.CORS(corsAllowedOrigins, corsAllowedMethods)(loggedRouter)
+	corsAllowedMethods := handlers.AllowedMethods([]string{"GET", "POST"})
+	corsAllowedHeaders := handlers.AllowedHeaders([]string{"Content-Type"})
+	return handlers.CORS(corsAllowedOrigins, corsAllowedMethods, corsAllowedHeaders)(loggedRouter)
 }
 
 func (api *api) solutionHandler(w http.ResponseWriter, r *http.Request) {
@@ -50,24 +111,426 @@ func (api *api) solutionHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	solvable, solutionNumber := api.solver.SolveBoard(board)
+	shape, err := parseShape(r)
+	if err != nil {
+		log.Println("Bad request due to invalid shape", err)
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, "invalid shape")
+
+		return
+	}
+
+	options, err := parseOptions(r)
+	if err != nil {
+		log.Println("Bad request due to invalid options", err)
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, "invalid options")
+
+		return
+	}
+
+	ctx, cancel := solveContext(r)
+	defer cancel()
+
+	solvability, solution, optimal := api.solver.SolveBoard(ctx, shape, board, options)
+
+	var certificate solver.Certificate
+	if solvability != solver.Solvable {
+		certificate, _ = api.enumerator.Certificate(shape, board, options)
+	}
+
+	log.Printf("Successful request for board %v, solvability: %v, solution: %v, optimal: %v", board, solvability, solution, optimal)
+	writeSolutionWithCertificate(w, shape, solvability, solution, optimal, certificate)
+}
+
+func (api *api) puzzleSolutionHandler(w http.ResponseWriter, r *http.Request) {
+	shape, board, options, err := parsePuzzle(w, r)
+	if err != nil {
+		log.Println("Bad request due to invalid puzzle", err)
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, "invalid puzzle")
+
+		return
+	}
+
+	ctx, cancel := solveContext(r)
+	defer cancel()
+
+	solvability, solution, optimal := api.solver.SolveBoard(ctx, shape, board, options)
+
+	var certificate solver.Certificate
+	if solvability != solver.Solvable {
+		certificate, _ = api.enumerator.Certificate(shape, board, options)
+	}
+
+	log.Printf("Successful request for puzzle %v, solvability: %v, solution: %v, optimal: %v", board, solvability, solution, optimal)
+	writeSolutionWithCertificate(w, shape, solvability, solution, optimal, certificate)
+}
+
+func (api *api) modularSolutionHandler(w http.ResponseWriter, r *http.Request) {
+	shape, board, stateCount, err := parseModularPuzzle(w, r)
+	if err != nil {
+		log.Println("Bad request due to invalid puzzle", err)
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, "invalid puzzle")
+
+		return
+	}
+
+	ctx, cancel := solveContext(r)
+	defer cancel()
+
+	solvable, clicks, err := api.modularSolver.SolveBoard(ctx, shape, board, stateCount)
+	if errors.Is(err, solver.ErrTooManyModularCombinations) {
+		log.Println("Bad request due to too many free variables", err)
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, "too many free variables")
+
+		return
+	}
+
+	// The elimination of a large board can take longer than the time limit, without a solution to return
+	if err != nil {
+		log.Println("Failed to solve the modular puzzle in time", err)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, "cannot solve the puzzle in time")
+
+		return
+	}
+
+	log.Printf("Successful request for modular puzzle %v with %v states, solvable: %v, clicks: %v", board, stateCount, solvable, clicks)
+	writeModularSolution(w, solvable, clicks)
+}
+
+func (api *api) graphSolutionHandler(w http.ResponseWriter, r *http.Request) {
+	graph, lights, err := parseGraphPuzzle(w, r)
+	if err != nil {
+		log.Println("Bad request due to invalid graph", err)
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, "invalid graph")
+
+		return
+	}
+
+	ctx, cancel := solveContext(r)
+	defer cancel()
+
+	solvable, solution, optimal := api.graphSolver.SolveGraph(ctx, graph, lights)
+
+	log.Printf("Successful request for graph puzzle %v, solvable: %v, solution: %v, optimal: %v", lights, solvable, solution, optimal)
+	solvability := solver.Unsolvable
+	if solvable {
+		solvability = solver.Solvable
+	}
+	writeSolution(w, graph.Shape(), solvability, solution, optimal)
+}
+
+func (api *api) allSolutionsHandler(w http.ResponseWriter, r *http.Request) {
+	page, err := parsePage(r)
+	if err != nil {
+		log.Println("Bad request due to invalid page", err)
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, "invalid page")
+
+		return
+	}
+
+	shape, board, options, err := parsePuzzle(w, r)
+	if err != nil {
+		log.Println("Bad request due to invalid puzzle", err)
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, "invalid puzzle")
+
+		return
+	}
+
+	solvability, space := api.enumerator.SolutionSpace(shape, board, options)
+
+	var solutions []utils.BitVector
+	if solvability == solver.Solvable {
+		solutions, err = getSolutionPage(space, page)
+		if err != nil {
+			log.Println("Bad request due to too many solutions to sort", err)
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, "too many solutions to sort")
+
+			return
+		}
+	}
+
+	log.Printf("Successful request for all solutions of puzzle %v, solvability: %v, dimension: %v", board, solvability, space.Dimension())
+	writeSolutionPage(w, shape, solvability, space.Dimension(), solutions)
+}
+
+func (api *api) optimalSolutionsHandler(w http.ResponseWriter, r *http.Request) {
+	shape, board, options, err := parsePuzzle(w, r)
+	if err != nil {
+		log.Println("Bad request due to invalid puzzle", err)
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, "invalid puzzle")
+
+		return
+	}
+
+	solvability, space := api.enumerator.SolutionSpace(shape, board, options)
+
+	var solutions []utils.BitVector
+	if solvability == solver.Solvable {
+		solutions, err = space.OptimalSolutions(shape, options)
+		if err != nil {
+			log.Println("Bad request due to too many solutions to search", err)
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, "too many solutions to search")
+
+			return
+		}
+	}
+
+	log.Printf("Successful request for optimal solutions of puzzle %v, solvability: %v, optimal solutions: %v", board, solvability, len(solutions))
+	writeSolutionPage(w, shape, solvability, space.Dimension(), solutions)
+}
+
+func (api *api) quietPatternsHandler(w http.ResponseWriter, r *http.Request) {
+	shape, err := parseShapeDescription(w, r)
+	if err != nil {
+		log.Println("Bad request due to invalid shape", err)
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, "invalid shape")
+
+		return
+	}
+
+	patterns := api.enumerator.QuietPatterns(shape)
+
+	log.Printf("Successful request for quiet patterns of a %vx%v board, patterns: %v", shape.RowCount, shape.ColumnCount, len(patterns))
+	writeQuietPatterns(w, shape, patterns)
+}
+
+func (api *api) nearestSolvableBoardHandler(w http.ResponseWriter, r *http.Request) {
+	shape, board, options, err := parsePuzzle(w, r)
+	if err != nil {
+		log.Println("Bad request due to invalid puzzle", err)
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, "invalid puzzle")
+
+		return
+	}
+
+	ctx, cancel := solveContext(r)
+	defer cancel()
+
+	corrections, solution, optimal, err := api.corrector.CorrectBoard(ctx, shape, board, options)
+	if errors.Is(err, solver.ErrTooManyParityChecks) {
+		log.Println("Bad request due to too many parity checks", err)
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, "too many parity checks")
+
+		return
+	}
+
+	if err != nil {
+		log.Println("Failed to solve the nearest solvable board", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, "cannot solve the nearest solvable board")
+
+		return
+	}
+
+	log.Printf("Successful request for the nearest solvable board to puzzle %v, corrections: %v, solution: %v, optimal: %v", board, corrections, solution, optimal)
+	writeCorrection(w, shape, corrections, solution, optimal)
+}
 
-	log.Printf("Successful request for board %v, solvable: %v, solution: %v", board, solvable, solutionNumber)
-	writeSolution(w, solvable, solutionNumber)
+// Returns the context of solving the puzzle of the request, which is done once the client goes away or the time is up
+func solveContext(r *http.Request) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(r.Context(), solveTimeout)
 }
 
-func parseBoard(r *http.Request) (uint32, error) {
+func parseBoard(r *http.Request) (utils.BitVector, error) {
 	vars := mux.Vars(r)
-	boardString := vars["board"]
+	return parseBoardNumber(vars["board"])
+}
+
+func parseOptions(r *http.Request) (solver.Options, error) {
+	var options solver.Options
+
+	query := r.URL.Query()
+
+	if targetString := query.Get("target"); targetString != "" {
+		target, err := parseBoardNumber(targetString)
+		if err != nil {
+			return options, err
+		}
+
+		options.Target = target
+	}
+
+	if targetMaskString := query.Get("targetMask"); targetMaskString != "" {
+		targetMask, err := parseBoardNumber(targetMaskString)
+		if err != nil {
+			return options, err
+		}
+
+		options.TargetMask = targetMask
+	}
+
+	tieBreaking, err := getTieBreaking(query.Get("tieBreaking"))
+	if err != nil {
+		return options, err
+	}
+	options.TieBreaking = tieBreaking
+
+	return options, nil
+}
+
+func parsePage(r *http.Request) (page, error) {
+	page := page{limit: defaultPageSize}
+	query := r.URL.Query()
+
+	if offsetString := query.Get("offset"); offsetString != "" {
+		// The offset is kept well below the overflow of the int type, as the limit is added to it
+		offset, err := strconv.ParseInt(offsetString, 10, 63)
+		if err != nil || offset < 0 {
+			return page, fmt.Errorf("invalid offset '%v'", offsetString)
+		}
+
+		page.offset = int(offset)
+	}
+
+	if limitString := query.Get("limit"); limitString != "" {
+		limit, err := strconv.Atoi(limitString)
+		if err != nil || limit < 1 || limit > maxPageSize {
+			return page, fmt.Errorf("the limit has to be between 1 and %v", maxPageSize)
+		}
+
+		page.limit = limit
+	}
+
+	switch sort := query.Get("sort"); sort {
+	case "":
+	case "clicks":
+		page.sorted = true
+	default:
+		return page, fmt.Errorf("unknown sort order '%v'", sort)
+	}
+
+	return page, nil
+}
+
+// Returns the solutions on the page, sorting all of them first if needed
+func getSolutionPage(space solver.SolutionSpace, page page) ([]utils.BitVector, error) {
+	if page.sorted {
+		solutions, err := space.SortedByClicks()
+		if err != nil {
+			return nil, err
+		}
+
+		if page.offset >= len(solutions) {
+			return nil, nil
+		}
 
+		end := page.offset + page.limit
+		if end > len(solutions) {
+			end = len(solutions)
+		}
+
+		return solutions[page.offset:end], nil
+	}
+
+	solutions := make([]utils.BitVector, 0, page.limit)
+	for i := page.offset; i < page.offset+page.limit && (space.Dimension() >= 63 || i < 1<<space.Dimension()); i++ {
+		solutions = append(solutions, space.Solution(uint64(i)))
+	}
+
+	return solutions, nil
+}
+
+// Parses a 5 by 5 board given as a base32 number
+func parseBoardNumber(boardString string) (utils.BitVector, error) {
 	board, err := strconv.ParseUint(boardString, 32, 32)
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+
+	if board >= 1<<solver.DefaultShape.CellCount() {
+		return nil, errors.New("invalid board number")
+	}
+
+	return utils.BitVector{board}, nil
+}
+
+func parseShape(r *http.Request) (solver.Shape, error) {
+	shape := solver.DefaultShape
+	query := r.URL.Query()
+
+	topology, err := getTopology(query.Get("topology"))
+	if err != nil {
+		return shape, err
+	}
+
+	grid, err := getGrid(query.Get("grid"))
+	if err != nil {
+		return shape, err
+	}
+
+	neighbourhood, err := getNeighbourhood(query.Get("neighbourhood"), shape.RowCount, shape.ColumnCount)
+	if err != nil {
+		return shape, err
+	}
+
+	shape.Topology = topology
+	shape.Grid = grid
+	shape.Neighbourhood = neighbourhood
+	return shape, shape.Validate()
+}
+
+func getTopology(name string) (solver.Topology, error) {
+	if name == "" {
+		return solver.PlanarTopology, nil
+	}
+
+	topology, exists := topologies[name]
+	if !exists {
+		return solver.PlanarTopology, fmt.Errorf("unknown topology '%v'", name)
+	}
+
+	return topology, nil
+}
+
+func getTieBreaking(name string) (solver.TieBreaking, error) {
+	if name == "" {
+		return solver.NoTieBreaking, nil
+	}
+
+	tieBreaking, exists := tieBreakingRules[name]
+	if !exists {
+		return solver.NoTieBreaking, fmt.Errorf("unknown tie-breaking rule '%v'", name)
+	}
+
+	return tieBreaking, nil
+}
+
+func getGrid(name string) (solver.Grid, error) {
+	if name == "" {
+		return solver.SquareGrid, nil
+	}
+
+	grid, exists := grids[name]
+	if !exists {
+		return solver.SquareGrid, fmt.Errorf("unknown grid '%v'", name)
+	}
+
+	return grid, nil
+}
+
+func getNeighbourhood(name string, rowCount, columnCount int) ([]solver.Offset, error) {
+	if name == "" {
+		return nil, nil
 	}
 
-	if board >= 1<<solver.MatrixSize {
-		return 0, errors.New("invalid board number")
+	preset, exists := neighbourhoods[name]
+	if !exists {
+		return nil, fmt.Errorf("unknown neighbourhood '%v'", name)
 	}
 
-	return uint32(board), nil
+	return preset.Offsets(rowCount, columnCount), nil
 }
@@ -1,28 +1,149 @@
 package api
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"server/solver"
+	"server/utils"
+	"strings"
 	"testing"
 )
 
 type mockSolver struct {
 	t         *testing.T
+	shape     solver.Shape
+	options   solver.Options
 	solutions map[uint32]struct {
-		solvable       bool
+		solvability    solver.Solvability
 		solutionNumber uint32
 	}
+	// Whether the solutions are returned as if the time was up before they could be proven optimal
+	notProvenOptimal bool
 }
 
-func (m *mockSolver) SolveBoard(board uint32) (bool, uint32) {
-	value, exists := m.solutions[board]
+func (m *mockSolver) SolveBoard(ctx context.Context, shape solver.Shape, board utils.BitVector, options solver.Options) (solver.Solvability, utils.BitVector, bool) {
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+		m.t.Fatal("Calling mock solver without a deadline")
+		return solver.Unsolvable, nil, false
+	}
+
+	if !reflect.DeepEqual(shape, m.shape) {
+		m.t.Fatalf("Calling mock solver with unexpected shape '%v'", shape)
+		return solver.Unsolvable, nil, false
+	}
+
+	if !reflect.DeepEqual(options, m.options) {
+		m.t.Fatalf("Calling mock solver with unexpected options '%v'", options)
+		return solver.Unsolvable, nil, false
+	}
+
+	value, exists := m.solutions[uint32(board[0])]
 	if exists {
-		return value.solvable, value.solutionNumber
+		return value.solvability, utils.BitVector{uint64(value.solutionNumber)}, !m.notProvenOptimal
 	} else {
 		m.t.Fatalf("Calling mock solver with unregistered input '%v'", board)
-		return false, 0
+		return solver.Unsolvable, nil, false
+	}
+}
+
+type mockModularSolver struct {
+	t          *testing.T
+	shape      solver.Shape
+	board      []int
+	stateCount int
+	solvable   bool
+	clicks     []int
+	err        error
+}
+
+func (m *mockModularSolver) SolveBoard(ctx context.Context, shape solver.Shape, board []int, stateCount int) (bool, []int, error) {
+	if !reflect.DeepEqual(shape, m.shape) || !reflect.DeepEqual(board, m.board) || stateCount != m.stateCount {
+		m.t.Fatalf("Calling mock modular solver with unexpected input '%v', '%v', '%v'", shape, board, stateCount)
+		return false, nil, nil
+	}
+
+	return m.solvable, m.clicks, m.err
+}
+
+type mockGraphSolver struct {
+	t        *testing.T
+	graph    solver.Graph
+	lights   utils.BitVector
+	solvable bool
+	solution utils.BitVector
+}
+
+func (m *mockGraphSolver) SolveGraph(ctx context.Context, graph solver.Graph, lights utils.BitVector) (bool, utils.BitVector, bool) {
+	if !reflect.DeepEqual(graph, m.graph) || !lights.Equal(m.lights) {
+		m.t.Fatalf("Calling mock graph solver with unexpected input '%v', '%v'", graph, lights)
+		return false, nil, false
+	}
+
+	return m.solvable, m.solution, true
+}
+
+type mockEnumerator struct {
+	t           *testing.T
+	shape       solver.Shape
+	board       utils.BitVector
+	options     solver.Options
+	solvability solver.Solvability
+	space       solver.SolutionSpace
+	patterns    []utils.BitVector
+	certificate solver.Certificate
+}
+
+func (m *mockEnumerator) SolutionSpace(shape solver.Shape, board utils.BitVector, options solver.Options) (solver.Solvability, solver.SolutionSpace) {
+	if !reflect.DeepEqual(shape, m.shape) || !board.Equal(m.board) || !reflect.DeepEqual(options, m.options) {
+		m.t.Fatalf("Calling mock enumerator with unexpected input '%v', '%v', '%v'", shape, board, options)
+		return solver.Unsolvable, solver.SolutionSpace{}
+	}
+
+	return m.solvability, m.space
+}
+
+func (m *mockEnumerator) Certificate(shape solver.Shape, board utils.BitVector, options solver.Options) (solver.Certificate, bool) {
+	if !reflect.DeepEqual(shape, m.shape) || !board.Equal(m.board) || !reflect.DeepEqual(options, m.options) {
+		m.t.Fatalf("Calling mock enumerator with unexpected input '%v', '%v', '%v'", shape, board, options)
+		return solver.Certificate{}, false
+	}
+
+	return m.certificate, len(m.certificate.FailingChecks) > 0
+}
+
+func (m *mockEnumerator) QuietPatterns(shape solver.Shape) []utils.BitVector {
+	if !reflect.DeepEqual(shape, m.shape) {
+		m.t.Fatalf("Calling mock enumerator with unexpected shape '%v'", shape)
+		return nil
+	}
+
+	return m.patterns
+}
+
+type mockCorrector struct {
+	t           *testing.T
+	shape       solver.Shape
+	board       utils.BitVector
+	options     solver.Options
+	corrections utils.BitVector
+	solution    utils.BitVector
+	err         error
+	// Whether the solution is returned as if the time was up before it could be proven optimal
+	notProvenOptimal bool
+}
+
+func (m *mockCorrector) CorrectBoard(ctx context.Context, shape solver.Shape, board utils.BitVector, options solver.Options) (utils.BitVector, utils.BitVector, bool, error) {
+	if !reflect.DeepEqual(shape, m.shape) || !board.Equal(m.board) || !reflect.DeepEqual(options, m.options) {
+		m.t.Fatalf("Calling mock corrector with unexpected input '%v', '%v', '%v'", shape, board, options)
+		return nil, nil, false, nil
 	}
+
+	return m.corrections, m.solution, !m.notProvenOptimal, m.err
 }
 
 func TestInvalidRequest(t *testing.T) {
@@ -30,6 +151,7 @@ func TestInvalidRequest(t *testing.T) {
 		name               string
 		httpMethod         string
 		httpPath           string
+		body               string
 		expectedStatusCode int
 	}{
 		{
@@ -56,28 +178,403 @@ func TestInvalidRequest(t *testing.T) {
 			httpPath:           "/api/solutions/cyp",
 			expectedStatusCode: http.StatusNotFound,
 		},
+		{
+			name:               "Unknown topology",
+			httpMethod:         "GET",
+			httpPath:           "/api/solutions/c1p?topology=klein",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Unknown neighbourhood",
+			httpMethod:         "GET",
+			httpPath:           "/api/solutions/c1p?neighbourhood=hexagon",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Out-of-bound target",
+			httpMethod:         "GET",
+			httpPath:           "/api/solutions/c1p?target=100000",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Invalid target",
+			httpMethod:         "GET",
+			httpPath:           "/api/solutions/c1p?target=xyz",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Invalid target mask",
+			httpMethod:         "GET",
+			httpPath:           "/api/solutions/c1p?targetMask=xyz",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Unknown tie-breaking rule",
+			httpMethod:         "GET",
+			httpPath:           "/api/solutions/c1p?tieBreaking=random",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Puzzle with unknown tie-breaking rule",
+			httpMethod:         "POST",
+			httpPath:           "/api/solutions",
+			body:               `{"rows":2,"columns":2,"board":[],"tieBreaking":"random"}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Optimal solutions of invalid puzzle",
+			httpMethod:         "POST",
+			httpPath:           "/api/optimal-solutions",
+			body:               `{"rows":2,"columns":2,"board":[4]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Unknown grid",
+			httpMethod:         "GET",
+			httpPath:           "/api/solutions/c1p?grid=triangular",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Hexagonal grid with neighbourhood",
+			httpMethod:         "GET",
+			httpPath:           "/api/solutions/c1p?grid=hexagonal&neighbourhood=moore",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Toroidal hexagonal grid with odd number of rows",
+			httpMethod:         "GET",
+			httpPath:           "/api/solutions/c1p?grid=hexagonal&topology=toroidal",
+			expectedStatusCode: http.StatusBadRequest,
+		},
 		{
 			name:               "Invalid method",
 			httpMethod:         "POST",
 			httpPath:           "/api/solutions/c1p",
 			expectedStatusCode: http.StatusMethodNotAllowed,
 		},
+		{
+			name:               "Invalid method for puzzles",
+			httpMethod:         "GET",
+			httpPath:           "/api/solutions",
+			expectedStatusCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:               "Puzzle is not JSON",
+			httpMethod:         "POST",
+			httpPath:           "/api/solutions",
+			body:               "rows=5&columns=5",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Puzzle with unknown field",
+			httpMethod:         "POST",
+			httpPath:           "/api/solutions",
+			body:               `{"rows":5,"columns":5,"board":[],"colours":3}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Puzzle without rows",
+			httpMethod:         "POST",
+			httpPath:           "/api/solutions",
+			body:               `{"columns":5,"board":[]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Puzzle with out-of-bound cell",
+			httpMethod:         "POST",
+			httpPath:           "/api/solutions",
+			body:               `{"rows":5,"columns":5,"board":[3,25]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Puzzle with unknown topology",
+			httpMethod:         "POST",
+			httpPath:           "/api/solutions",
+			body:               `{"rows":5,"columns":5,"topology":"klein","board":[]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Puzzle with unknown grid",
+			httpMethod:         "POST",
+			httpPath:           "/api/solutions",
+			body:               `{"rows":5,"columns":5,"grid":"triangular","board":[]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Puzzle with hexagonal grid and offsets",
+			httpMethod:         "POST",
+			httpPath:           "/api/solutions",
+			body:               `{"rows":5,"columns":5,"grid":"hexagonal","offsets":[{"row":0,"column":0}],"board":[]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Puzzle with missing toggles",
+			httpMethod:         "POST",
+			httpPath:           "/api/solutions",
+			body:               `{"rows":1,"columns":3,"toggles":[[0,1],[1]],"board":[]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Puzzle with out-of-bound toggled cell",
+			httpMethod:         "POST",
+			httpPath:           "/api/solutions",
+			body:               `{"rows":1,"columns":3,"toggles":[[0,1],[1],[2,3]],"board":[]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Puzzle with both neighbourhood and toggles",
+			httpMethod:         "POST",
+			httpPath:           "/api/solutions",
+			body:               `{"rows":1,"columns":3,"neighbourhood":"moore","toggles":[[0,1],[1],[2]],"board":[]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Modular puzzle with too many states",
+			httpMethod:         "POST",
+			httpPath:           "/api/modular-solutions",
+			body:               `{"rows":1,"columns":3,"states":65,"board":[0,1,2]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Modular puzzle with missing cell state",
+			httpMethod:         "POST",
+			httpPath:           "/api/modular-solutions",
+			body:               `{"rows":1,"columns":3,"states":3,"board":[0,1]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Modular puzzle with out-of-bound cell state",
+			httpMethod:         "POST",
+			httpPath:           "/api/modular-solutions",
+			body:               `{"rows":1,"columns":3,"states":3,"board":[0,1,3]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Puzzle with both neighbourhood and offsets",
+			httpMethod:         "POST",
+			httpPath:           "/api/solutions",
+			body:               `{"rows":5,"columns":5,"neighbourhood":"moore","offsets":[{"row":0,"column":0}],"board":[]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Puzzle with out-of-bound masked cell",
+			httpMethod:         "POST",
+			httpPath:           "/api/solutions",
+			body:               `{"rows":3,"columns":3,"mask":[1,3,4,5,9],"board":[]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Puzzle with empty mask",
+			httpMethod:         "POST",
+			httpPath:           "/api/solutions",
+			body:               `{"rows":3,"columns":3,"mask":[],"board":[]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Puzzle with out-of-bound target",
+			httpMethod:         "POST",
+			httpPath:           "/api/solutions",
+			body:               `{"rows":3,"columns":3,"board":[],"target":[9]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Puzzle with target on missing cell",
+			httpMethod:         "POST",
+			httpPath:           "/api/solutions",
+			body:               `{"rows":3,"columns":3,"mask":[1,3,4,5,7],"board":[],"target":[0]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Puzzle with out-of-bound target mask",
+			httpMethod:         "POST",
+			httpPath:           "/api/solutions",
+			body:               `{"rows":3,"columns":3,"board":[],"targetMask":[-1]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Puzzle with cost of out-of-bound cell",
+			httpMethod:         "POST",
+			httpPath:           "/api/solutions",
+			body:               `{"rows":2,"columns":2,"board":[],"costs":{"4":1}}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Puzzle with negative cost",
+			httpMethod:         "POST",
+			httpPath:           "/api/solutions",
+			body:               `{"rows":2,"columns":2,"board":[],"costs":{"1":-1}}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Puzzle with invalid cost map",
+			httpMethod:         "POST",
+			httpPath:           "/api/solutions",
+			body:               `{"rows":2,"columns":2,"board":[],"costs":{"first":1}}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Puzzle with out-of-bound forbidden click",
+			httpMethod:         "POST",
+			httpPath:           "/api/solutions",
+			body:               `{"rows":2,"columns":2,"board":[],"forbidden":[4]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Puzzle with mandatory click on missing cell",
+			httpMethod:         "POST",
+			httpPath:           "/api/solutions",
+			body:               `{"rows":3,"columns":3,"mask":[1,3,4,5,7],"board":[],"mandatory":[0]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Puzzle with click that is both forbidden and mandatory",
+			httpMethod:         "POST",
+			httpPath:           "/api/solutions",
+			body:               `{"rows":2,"columns":2,"board":[],"forbidden":[0,1],"mandatory":[1]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Puzzle with lit missing cell",
+			httpMethod:         "POST",
+			httpPath:           "/api/solutions",
+			body:               `{"rows":3,"columns":3,"mask":[1,3,4,5,7],"board":[0]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Modular puzzle with missing cell in non-zero state",
+			httpMethod:         "POST",
+			httpPath:           "/api/modular-solutions",
+			body:               `{"rows":1,"columns":3,"mask":[0,1],"states":3,"board":[0,1,2]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Graph with GET method",
+			httpMethod:         "GET",
+			httpPath:           "/api/graph-solutions",
+			expectedStatusCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:               "Graph without vertices",
+			httpMethod:         "POST",
+			httpPath:           "/api/graph-solutions",
+			body:               `{"vertices":0,"edges":[],"lights":[]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Graph with out-of-bound vertex in edge",
+			httpMethod:         "POST",
+			httpPath:           "/api/graph-solutions",
+			body:               `{"vertices":3,"edges":[[0,1],[1,3]],"lights":[0]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Graph with invalid edge",
+			httpMethod:         "POST",
+			httpPath:           "/api/graph-solutions",
+			body:               `{"vertices":3,"edges":[[0,1,2]],"lights":[0]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Graph with both edges and neighbours",
+			httpMethod:         "POST",
+			httpPath:           "/api/graph-solutions",
+			body:               `{"edges":[[0,1]],"neighbours":[[1],[]],"lights":[0]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Graph with mismatching number of vertices",
+			httpMethod:         "POST",
+			httpPath:           "/api/graph-solutions",
+			body:               `{"vertices":3,"neighbours":[[1],[]],"lights":[0]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Graph with out-of-bound light",
+			httpMethod:         "POST",
+			httpPath:           "/api/graph-solutions",
+			body:               `{"neighbours":[[1],[]],"lights":[2]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Quiet patterns of invalid shape",
+			httpMethod:         "POST",
+			httpPath:           "/api/quiet-patterns",
+			body:               `{"rows":3,"columns":3,"topology":"spherical"}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Quiet patterns with unknown field",
+			httpMethod:         "POST",
+			httpPath:           "/api/quiet-patterns",
+			body:               `{"rows":3,"columns":3,"board":[]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "All solutions with invalid puzzle",
+			httpMethod:         "POST",
+			httpPath:           "/api/all-solutions",
+			body:               `{"rows":0,"columns":3,"board":[]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "All solutions with negative offset",
+			httpMethod:         "POST",
+			httpPath:           "/api/all-solutions?offset=-1",
+			body:               `{"rows":3,"columns":3,"board":[]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "All solutions with too large offset",
+			httpMethod:         "POST",
+			httpPath:           "/api/all-solutions?offset=9223372036854775807",
+			body:               `{"rows":3,"columns":3,"board":[]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "All solutions with zero limit",
+			httpMethod:         "POST",
+			httpPath:           "/api/all-solutions?limit=0",
+			body:               `{"rows":3,"columns":3,"board":[]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "All solutions with too large limit",
+			httpMethod:         "POST",
+			httpPath:           "/api/all-solutions?limit=101",
+			body:               `{"rows":3,"columns":3,"board":[]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "All solutions with unknown sort order",
+			httpMethod:         "POST",
+			httpPath:           "/api/all-solutions?sort=cost",
+			body:               `{"rows":3,"columns":3,"board":[]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Nearest solvable board of an invalid puzzle",
+			httpMethod:         "POST",
+			httpPath:           "/api/nearest-solvable-board",
+			body:               `{"rows":3,"columns":3,"board":[9]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
 	}
 
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
 			// Arrage
 			solver := &mockSolver{
-				t: t,
+				t:     t,
+				shape: solver.DefaultShape,
 				solutions: map[uint32]struct {
-					solvable       bool
+					solvability    solver.Solvability
 					solutionNumber uint32
 				}{},
 			}
-			api := New(solver)
+			api := New(solver, &mockModularSolver{t: t}, &mockGraphSolver{t: t}, &mockEnumerator{t: t}, &mockCorrector{t: t})
 			handler := api.SetupHttpHandler()
 
-			request := httptest.NewRequest(testCase.httpMethod, testCase.httpPath, nil)
+			request := httptest.NewRequest(testCase.httpMethod, testCase.httpPath, strings.NewReader(testCase.body))
 			response := httptest.NewRecorder()
 
 			// Act
@@ -96,56 +593,155 @@ func TestSuccessfulRequest(t *testing.T) {
 	testCases := []struct {
 		name                 string
 		boardString          string
+		query                string
+		shape                solver.Shape
+		options              solver.Options
 		boardNumber          uint32
-		solvable             bool
+		solvability          solver.Solvability
 		solutionNumber       uint32
+		certificate          solver.Certificate
 		expectedResponseBody string
 	}{
 		{
 			name:                 "No solution",
+			shape:                solver.DefaultShape,
 			boardString:          "none",
 			boardNumber:          778990,
-			solvable:             false,
+			solvability:          solver.Unsolvable,
 			solutionNumber:       0b0,
 			expectedResponseBody: "{\"hasSolution\":false,\"solution\":null}\n",
 		},
+		{
+			name:                 "No solution with certificate",
+			shape:                solver.DefaultShape,
+			boardString:          "1",
+			boardNumber:          1,
+			solvability:          solver.Unsolvable,
+			solutionNumber:       0b0,
+			certificate:          solver.Certificate{FailingChecks: []utils.BitVector{{0b10101_10101_00000_10101_10101}, {0b01110_10101_11011_10101_01110}}},
+			expectedResponseBody: "{\"hasSolution\":false,\"solution\":null,\"certificate\":{\"witness\":[0,2,4,5,7,9,15,17,19,20,22,24],\"failingChecks\":[[0,2,4,5,7,9,15,17,19,20,22,24],[1,2,3,5,7,9,10,11,13,14,15,17,19,21,22,23]]}}\n",
+		},
 		{
 			name:                 "Empty solution",
+			shape:                solver.DefaultShape,
 			boardString:          "emptv",
 			boardNumber:          15427519,
-			solvable:             true,
+			solvability:          solver.Solvable,
 			solutionNumber:       0b0,
 			expectedResponseBody: "{\"hasSolution\":true,\"solution\":[]}\n",
 		},
 		{
 			name:                 "Solution with multpile clicks",
+			shape:                solver.DefaultShape,
 			boardString:          "c1p",
 			boardNumber:          12345,
-			solvable:             true,
+			solvability:          solver.Solvable,
 			solutionNumber:       0b1_1111,
 			expectedResponseBody: "{\"hasSolution\":true,\"solution\":[0,1,2,3,4]}\n",
 		},
+		{
+			name:                 "Toroidal board",
+			boardString:          "c1p",
+			query:                "?topology=toroidal",
+			shape:                solver.Shape{RowCount: 5, ColumnCount: 5, Topology: solver.ToroidalTopology},
+			boardNumber:          12345,
+			solvability:          solver.Solvable,
+			solutionNumber:       0b1_0000_0000_0000_0000_0000_0001,
+			expectedResponseBody: "{\"hasSolution\":true,\"solution\":[0,24]}\n",
+		},
+		{
+			name:                 "Toroidal board with Moore neighbourhood",
+			boardString:          "c1p",
+			query:                "?topology=toroidal&neighbourhood=moore",
+			shape:                solver.Shape{RowCount: 5, ColumnCount: 5, Topology: solver.ToroidalTopology, Neighbourhood: solver.MooreNeighbourhood.Offsets(5, 5)},
+			boardNumber:          12345,
+			solvability:          solver.Unsolvable,
+			solutionNumber:       0b0,
+			expectedResponseBody: "{\"hasSolution\":false,\"solution\":null}\n",
+		},
+		{
+			name:                 "Explicitly planar board",
+			boardString:          "c1p",
+			query:                "?topology=planar",
+			shape:                solver.DefaultShape,
+			boardNumber:          12345,
+			solvability:          solver.Solvable,
+			solutionNumber:       0b1_0000,
+			expectedResponseBody: "{\"hasSolution\":true,\"solution\":[4]}\n",
+		},
+		{
+			name:                 "Board with target",
+			boardString:          "c1p",
+			query:                "?target=1",
+			shape:                solver.DefaultShape,
+			options:              solver.Options{Target: utils.BitVector{0b1}},
+			boardNumber:          12345,
+			solvability:          solver.Solvable,
+			solutionNumber:       0b1_0000,
+			expectedResponseBody: "{\"hasSolution\":true,\"solution\":[4]}\n",
+		},
+		{
+			name:                 "Board with target mask",
+			boardString:          "c1p",
+			query:                "?target=1&targetMask=3",
+			shape:                solver.DefaultShape,
+			options:              solver.Options{Target: utils.BitVector{0b1}, TargetMask: utils.BitVector{0b11}},
+			boardNumber:          12345,
+			solvability:          solver.Solvable,
+			solutionNumber:       0b1_0000,
+			expectedResponseBody: "{\"hasSolution\":true,\"solution\":[4]}\n",
+		},
+		{
+			name:                 "Hexagonal board",
+			boardString:          "c1p",
+			query:                "?grid=hexagonal",
+			shape:                solver.Shape{RowCount: 5, ColumnCount: 5, Grid: solver.HexagonalGrid},
+			boardNumber:          12345,
+			solvability:          solver.Solvable,
+			solutionNumber:       0b1_0001,
+			expectedResponseBody: "{\"hasSolution\":true,\"solution\":[0,4]}\n",
+		},
+		{
+			name:                 "Board with tie-breaking",
+			boardString:          "c1p",
+			query:                "?tieBreaking=fewestRows",
+			shape:                solver.DefaultShape,
+			options:              solver.Options{TieBreaking: solver.FewestRowsTieBreaking},
+			boardNumber:          12345,
+			solvability:          solver.Solvable,
+			solutionNumber:       0b1_0001,
+			expectedResponseBody: "{\"hasSolution\":true,\"solution\":[0,4]}\n",
+		},
 	}
 
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
 			// Arrage
 			solver := &mockSolver{
-				t: t,
+				t:       t,
+				shape:   testCase.shape,
+				options: testCase.options,
 				solutions: map[uint32]struct {
-					solvable       bool
+					solvability    solver.Solvability
 					solutionNumber uint32
 				}{
 					testCase.boardNumber: {
-						solvable:       testCase.solvable,
+						solvability:    testCase.solvability,
 						solutionNumber: testCase.solutionNumber,
 					},
 				},
 			}
-			api := New(solver)
+			enumerator := &mockEnumerator{
+				t:           t,
+				shape:       testCase.shape,
+				board:       utils.BitVector{uint64(testCase.boardNumber)},
+				options:     testCase.options,
+				certificate: testCase.certificate,
+			}
+			api := New(solver, &mockModularSolver{t: t}, &mockGraphSolver{t: t}, enumerator, nil)
 			handler := api.SetupHttpHandler()
 
-			request := httptest.NewRequest("GET", "/api/solutions/"+testCase.boardString, nil)
+			request := httptest.NewRequest("GET", "/api/solutions/"+testCase.boardString+testCase.query, nil)
 			response := httptest.NewRecorder()
 
 			// Act
@@ -171,3 +767,756 @@ func TestSuccessfulRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestSuccessfulPuzzleRequest(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		body                 string
+		shape                solver.Shape
+		options              solver.Options
+		boardNumber          uint32
+		solvability          solver.Solvability
+		solutionNumber       uint32
+		certificate          solver.Certificate
+		notProvenOptimal     bool
+		expectedResponseBody string
+	}{
+		{
+			name:                 "Default puzzle",
+			body:                 `{"rows":3,"columns":3,"board":[0,4,8]}`,
+			shape:                solver.Shape{RowCount: 3, ColumnCount: 3},
+			boardNumber:          0b100_010_001,
+			solvability:          solver.Solvable,
+			solutionNumber:       0b100_000_001,
+			expectedResponseBody: "{\"hasSolution\":true,\"solution\":[0,8]}\n",
+		},
+		{
+			name:                 "Puzzle with neighbourhood preset",
+			body:                 `{"rows":2,"columns":4,"topology":"toroidal","neighbourhood":"cross","board":[7]}`,
+			shape:                solver.Shape{RowCount: 2, ColumnCount: 4, Topology: solver.ToroidalTopology, Neighbourhood: solver.CrossNeighbourhood.Offsets(2, 4)},
+			boardNumber:          0b1000_0000,
+			solvability:          solver.Unsolvable,
+			solutionNumber:       0b0,
+			expectedResponseBody: "{\"hasSolution\":false,\"solution\":null}\n",
+		},
+		{
+			name:                 "Puzzle with custom offsets",
+			body:                 `{"rows":1,"columns":6,"offsets":[{"row":0,"column":0},{"row":0,"column":2}],"board":[1,3]}`,
+			shape:                solver.Shape{RowCount: 1, ColumnCount: 6, Neighbourhood: []solver.Offset{{Row: 0, Column: 0}, {Row: 0, Column: 2}}},
+			boardNumber:          0b001010,
+			solvability:          solver.Solvable,
+			solutionNumber:       0b000010,
+			expectedResponseBody: "{\"hasSolution\":true,\"solution\":[1]}\n",
+		},
+		{
+			name:                 "Puzzle with toggles",
+			body:                 `{"rows":1,"columns":3,"toggles":[[0,1],[1],[0,2]],"board":[0,1]}`,
+			shape:                solver.Shape{RowCount: 1, ColumnCount: 3, FlipVectors: []utils.BitVector{{0b011}, {0b010}, {0b101}}},
+			boardNumber:          0b011,
+			solvability:          solver.Solvable,
+			solutionNumber:       0b001,
+			expectedResponseBody: "{\"hasSolution\":true,\"solution\":[0]}\n",
+		},
+		{
+			name:                 "Puzzle with target",
+			body:                 `{"rows":3,"columns":3,"board":[0,4,8],"target":[2,4,6]}`,
+			shape:                solver.Shape{RowCount: 3, ColumnCount: 3},
+			options:              solver.Options{Target: utils.BitVector{0b001_010_100}},
+			boardNumber:          0b100_010_001,
+			solvability:          solver.Solvable,
+			solutionNumber:       0b101_000_101,
+			expectedResponseBody: "{\"hasSolution\":true,\"solution\":[0,2,6,8]}\n",
+		},
+		{
+			name:                 "Puzzle with target mask",
+			body:                 `{"rows":3,"columns":3,"board":[0,4,8],"targetMask":[4]}`,
+			shape:                solver.Shape{RowCount: 3, ColumnCount: 3},
+			options:              solver.Options{TargetMask: utils.BitVector{0b000_010_000}},
+			boardNumber:          0b100_010_001,
+			solvability:          solver.Solvable,
+			solutionNumber:       0b000_000_010,
+			expectedResponseBody: "{\"hasSolution\":true,\"solution\":[1]}\n",
+		},
+		{
+			name:                 "Puzzle with costs",
+			body:                 `{"rows":2,"columns":2,"board":[0,1,2,3],"costs":{"0":5,"3":0}}`,
+			shape:                solver.Shape{RowCount: 2, ColumnCount: 2},
+			options:              solver.Options{Costs: []int{5, 1, 1, 0}},
+			boardNumber:          0b11_11,
+			solvability:          solver.Solvable,
+			solutionNumber:       0b11_11,
+			expectedResponseBody: "{\"hasSolution\":true,\"solution\":[0,1,2,3]}\n",
+		},
+		{
+			name:                 "Puzzle with hexagonal grid",
+			body:                 `{"rows":2,"columns":4,"topology":"toroidal","grid":"hexagonal","board":[0]}`,
+			shape:                solver.Shape{RowCount: 2, ColumnCount: 4, Topology: solver.ToroidalTopology, Grid: solver.HexagonalGrid},
+			boardNumber:          0b0000_0001,
+			solvability:          solver.Solvable,
+			solutionNumber:       0b0011_0010,
+			expectedResponseBody: "{\"hasSolution\":true,\"solution\":[1,4,5]}\n",
+		},
+		{
+			name:                 "Puzzle with mask",
+			body:                 `{"rows":3,"columns":3,"mask":[1,3,4,5,7],"board":[1,7]}`,
+			shape:                solver.Shape{RowCount: 3, ColumnCount: 3, Mask: utils.BitVector{0b010_111_010}},
+			boardNumber:          0b010_000_010,
+			solvability:          solver.Solvable,
+			solutionNumber:       0b010_101_010,
+			expectedResponseBody: "{\"hasSolution\":true,\"solution\":[1,3,5,7]}\n",
+		},
+		{
+			name:                 "Puzzle with forbidden and mandatory clicks",
+			body:                 `{"rows":2,"columns":2,"board":[0,1],"forbidden":[2],"mandatory":[0,1]}`,
+			shape:                solver.Shape{RowCount: 2, ColumnCount: 2},
+			options:              solver.Options{ForbiddenClicks: utils.BitVector{0b01_00}, MandatoryClicks: utils.BitVector{0b00_11}},
+			boardNumber:          0b00_11,
+			solvability:          solver.Solvable,
+			solutionNumber:       0b10_11,
+			expectedResponseBody: "{\"hasSolution\":true,\"solution\":[0,1,3]}\n",
+		},
+		{
+			name:                 "Puzzle with tie-breaking",
+			body:                 `{"rows":2,"columns":2,"board":[0,1],"tieBreaking":"centre"}`,
+			shape:                solver.Shape{RowCount: 2, ColumnCount: 2},
+			options:              solver.Options{TieBreaking: solver.CentreTieBreaking},
+			boardNumber:          0b00_11,
+			solvability:          solver.Solvable,
+			solutionNumber:       0b11_00,
+			expectedResponseBody: "{\"hasSolution\":true,\"solution\":[2,3]}\n",
+		},
+		{
+			name:                 "Puzzle unsolvable under constraints",
+			body:                 `{"rows":2,"columns":2,"board":[0],"forbidden":[0]}`,
+			shape:                solver.Shape{RowCount: 2, ColumnCount: 2},
+			options:              solver.Options{ForbiddenClicks: utils.BitVector{0b00_01}},
+			boardNumber:          0b00_01,
+			solvability:          solver.UnsolvableUnderConstraints,
+			solutionNumber:       0b0,
+			certificate:          solver.Certificate{FailingChecks: []utils.BitVector{{0b00_01}}},
+			expectedResponseBody: "{\"hasSolution\":false,\"solution\":null,\"unsolvableUnderConstraints\":true,\"certificate\":{\"witness\":[0],\"failingChecks\":[[0]]}}\n",
+		},
+		{
+			name:                 "Puzzle with a solution not proven optimal in time",
+			body:                 `{"rows":3,"columns":3,"board":[0,4,8]}`,
+			shape:                solver.Shape{RowCount: 3, ColumnCount: 3},
+			boardNumber:          0b100_010_001,
+			solvability:          solver.Solvable,
+			solutionNumber:       0b100_010_001,
+			notProvenOptimal:     true,
+			expectedResponseBody: "{\"hasSolution\":true,\"solution\":[0,4,8],\"notProvenOptimal\":true}\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Arrage
+			solver := &mockSolver{
+				t:       t,
+				shape:   testCase.shape,
+				options: testCase.options,
+				solutions: map[uint32]struct {
+					solvability    solver.Solvability
+					solutionNumber uint32
+				}{
+					testCase.boardNumber: {
+						solvability:    testCase.solvability,
+						solutionNumber: testCase.solutionNumber,
+					},
+				},
+				notProvenOptimal: testCase.notProvenOptimal,
+			}
+			enumerator := &mockEnumerator{
+				t:           t,
+				shape:       testCase.shape,
+				board:       utils.BitVector{uint64(testCase.boardNumber)},
+				options:     testCase.options,
+				certificate: testCase.certificate,
+			}
+			api := New(solver, &mockModularSolver{t: t}, &mockGraphSolver{t: t}, enumerator, nil)
+			handler := api.SetupHttpHandler()
+
+			request := httptest.NewRequest("POST", "/api/solutions", strings.NewReader(testCase.body))
+			response := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(response, request)
+
+			// Assert
+			result := response.Result()
+			if result.StatusCode != http.StatusOK {
+				t.Errorf("Incorrect status code: expected %v, got %v", http.StatusOK, result.StatusCode)
+			}
+
+			bodyBytes, err := io.ReadAll(result.Body)
+			if err != nil {
+				t.Fatalf("Error while reading response body %v", err)
+			}
+			body := string(bodyBytes)
+			if body != testCase.expectedResponseBody {
+				t.Errorf("Incorrect response body: expected '%v', got '%v'", testCase.expectedResponseBody, body)
+			}
+		})
+	}
+}
+
+func TestSuccessfulModularRequest(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		body                 string
+		shape                solver.Shape
+		board                []int
+		stateCount           int
+		solvable             bool
+		clicks               []int
+		err                  error
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:                 "Puzzle with solution",
+			body:                 `{"rows":1,"columns":3,"states":3,"board":[2,0,1]}`,
+			shape:                solver.Shape{RowCount: 1, ColumnCount: 3},
+			board:                []int{2, 0, 1},
+			stateCount:           3,
+			solvable:             true,
+			clicks:               []int{0, 1, 2},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"hasSolution\":true,\"clicks\":[0,1,2]}\n",
+		},
+		{
+			name:                 "Puzzle without solution",
+			body:                 `{"rows":2,"columns":2,"topology":"toroidal","states":5,"board":[1,0,0,4]}`,
+			shape:                solver.Shape{RowCount: 2, ColumnCount: 2, Topology: solver.ToroidalTopology},
+			board:                []int{1, 0, 0, 4},
+			stateCount:           5,
+			solvable:             false,
+			clicks:               nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"hasSolution\":false,\"clicks\":null}\n",
+		},
+		{
+			name:                 "Puzzle with composite number of states",
+			body:                 `{"rows":2,"columns":2,"states":6,"board":[3,3,0,0]}`,
+			shape:                solver.Shape{RowCount: 2, ColumnCount: 2},
+			board:                []int{3, 3, 0, 0},
+			stateCount:           6,
+			solvable:             true,
+			clicks:               []int{1, 1, 5, 5},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"hasSolution\":true,\"clicks\":[1,1,5,5]}\n",
+		},
+		{
+			name:               "Puzzle with too many free variables",
+			body:               `{"rows":1,"columns":3,"offsets":[{"row":5,"column":0}],"states":7,"board":[1,2,3]}`,
+			shape:              solver.Shape{RowCount: 1, ColumnCount: 3, Neighbourhood: []solver.Offset{{Row: 5, Column: 0}}},
+			board:              []int{1, 2, 3},
+			stateCount:         7,
+			err:                fmt.Errorf("solving the board: %w", solver.ErrTooManyModularCombinations),
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Puzzle not solved in time",
+			body:               `{"rows":2,"columns":2,"states":3,"board":[1,2,0,1]}`,
+			shape:              solver.Shape{RowCount: 2, ColumnCount: 2},
+			board:              []int{1, 2, 0, 1},
+			stateCount:         3,
+			err:                context.DeadlineExceeded,
+			expectedStatusCode: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Arrage
+			modularSolver := &mockModularSolver{
+				t:          t,
+				shape:      testCase.shape,
+				board:      testCase.board,
+				stateCount: testCase.stateCount,
+				solvable:   testCase.solvable,
+				clicks:     testCase.clicks,
+				err:        testCase.err,
+			}
+			api := New(nil, modularSolver, nil, nil, nil)
+			handler := api.SetupHttpHandler()
+
+			request := httptest.NewRequest("POST", "/api/modular-solutions", strings.NewReader(testCase.body))
+			response := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(response, request)
+
+			// Assert
+			result := response.Result()
+			if result.StatusCode != testCase.expectedStatusCode {
+				t.Errorf("Incorrect status code: expected %v, got %v", testCase.expectedStatusCode, result.StatusCode)
+			}
+
+			if testCase.expectedStatusCode != http.StatusOK {
+				return
+			}
+
+			bodyBytes, err := io.ReadAll(result.Body)
+			if err != nil {
+				t.Fatalf("Error while reading response body %v", err)
+			}
+			body := string(bodyBytes)
+			if body != testCase.expectedResponseBody {
+				t.Errorf("Incorrect response body: expected '%v', got '%v'", testCase.expectedResponseBody, body)
+			}
+		})
+	}
+}
+
+func TestSuccessfulGraphRequest(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		body                 string
+		graph                solver.Graph
+		lights               utils.BitVector
+		solvable             bool
+		solution             utils.BitVector
+		expectedResponseBody string
+	}{
+		{
+			name:                 "Graph given by edges",
+			body:                 `{"vertices":4,"edges":[[0,1],[1,2],[2,3],[3,0]],"lights":[0,2]}`,
+			graph:                solver.Graph{Neighbours: [][]int{{1}, {2}, {3}, {0}}},
+			lights:               utils.BitVector{0b0101},
+			solvable:             true,
+			solution:             utils.BitVector{0b1111},
+			expectedResponseBody: "{\"hasSolution\":true,\"solution\":[0,1,2,3]}\n",
+		},
+		{
+			name:                 "Graph given by neighbours",
+			body:                 `{"neighbours":[[1,2],[],[]],"lights":[1]}`,
+			graph:                solver.Graph{Neighbours: [][]int{{1, 2}, {}, {}}},
+			lights:               utils.BitVector{0b010},
+			solvable:             false,
+			solution:             nil,
+			expectedResponseBody: "{\"hasSolution\":false,\"solution\":null}\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Arrage
+			graphSolver := &mockGraphSolver{
+				t:        t,
+				graph:    testCase.graph,
+				lights:   testCase.lights,
+				solvable: testCase.solvable,
+				solution: testCase.solution,
+			}
+			api := New(nil, nil, graphSolver, nil, nil)
+			handler := api.SetupHttpHandler()
+
+			request := httptest.NewRequest("POST", "/api/graph-solutions", strings.NewReader(testCase.body))
+			response := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(response, request)
+
+			// Assert
+			result := response.Result()
+			if result.StatusCode != http.StatusOK {
+				t.Errorf("Incorrect status code: expected %v, got %v", http.StatusOK, result.StatusCode)
+			}
+
+			bodyBytes, err := io.ReadAll(result.Body)
+			if err != nil {
+				t.Fatalf("Error while reading response body %v", err)
+			}
+			body := string(bodyBytes)
+			if body != testCase.expectedResponseBody {
+				t.Errorf("Incorrect response body: expected '%v', got '%v'", testCase.expectedResponseBody, body)
+			}
+		})
+	}
+}
+
+func TestSuccessfulAllSolutionsRequest(t *testing.T) {
+	// Two basis vectors on a 2x2 board, giving the solutions {0}, {0,1}, {0,1,2,3}, {0,2,3} in the enumeration order
+	space := solver.SolutionSpace{
+		Particular: utils.BitVector{0b00_01},
+		Basis:      []utils.BitVector{{0b00_10}, {0b11_00}},
+	}
+
+	testCases := []struct {
+		name                 string
+		query                string
+		body                 string
+		options              solver.Options
+		solvability          solver.Solvability
+		space                solver.SolutionSpace
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:                 "First page",
+			body:                 `{"rows":2,"columns":2,"board":[1,2]}`,
+			solvability:          solver.Solvable,
+			space:                space,
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"hasSolution\":true,\"dimension\":2,\"solutions\":[[0],[0,1],[0,1,2,3],[0,2,3]]}\n",
+		},
+		{
+			name:                 "Page with offset and limit",
+			query:                "?offset=1&limit=2",
+			body:                 `{"rows":2,"columns":2,"board":[1,2]}`,
+			solvability:          solver.Solvable,
+			space:                space,
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"hasSolution\":true,\"dimension\":2,\"solutions\":[[0,1],[0,1,2,3]]}\n",
+		},
+		{
+			name:                 "Page after the last solution",
+			query:                "?offset=4",
+			body:                 `{"rows":2,"columns":2,"board":[1,2]}`,
+			solvability:          solver.Solvable,
+			space:                space,
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"hasSolution\":true,\"dimension\":2,\"solutions\":[]}\n",
+		},
+		{
+			name:                 "Sorted by clicks",
+			query:                "?sort=clicks&offset=1&limit=2",
+			body:                 `{"rows":2,"columns":2,"board":[1,2]}`,
+			solvability:          solver.Solvable,
+			space:                space,
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"hasSolution\":true,\"dimension\":2,\"solutions\":[[0,1],[0,2,3]]}\n",
+		},
+		{
+			name:                 "Unsolvable under constraints",
+			body:                 `{"rows":2,"columns":2,"board":[1,2],"forbidden":[0]}`,
+			options:              solver.Options{ForbiddenClicks: utils.BitVector{0b00_01}},
+			solvability:          solver.UnsolvableUnderConstraints,
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"hasSolution\":false,\"unsolvableUnderConstraints\":true,\"dimension\":0,\"solutions\":[]}\n",
+		},
+		{
+			name:               "Too many solutions to sort",
+			query:              "?sort=clicks",
+			body:               `{"rows":2,"columns":2,"board":[1,2]}`,
+			solvability:        solver.Solvable,
+			space:              solver.SolutionSpace{Particular: utils.BitVector{0}, Basis: make([]utils.BitVector, solver.MaxSortedDimension+1)},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Arrage
+			enumerator := &mockEnumerator{
+				t:           t,
+				shape:       solver.Shape{RowCount: 2, ColumnCount: 2},
+				board:       utils.BitVector{0b01_10},
+				options:     testCase.options,
+				solvability: testCase.solvability,
+				space:       testCase.space,
+			}
+			api := New(nil, nil, nil, enumerator, nil)
+			handler := api.SetupHttpHandler()
+
+			request := httptest.NewRequest("POST", "/api/all-solutions"+testCase.query, strings.NewReader(testCase.body))
+			response := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(response, request)
+
+			// Assert
+			result := response.Result()
+			if result.StatusCode != testCase.expectedStatusCode {
+				t.Errorf("Incorrect status code: expected %v, got %v", testCase.expectedStatusCode, result.StatusCode)
+			}
+
+			if testCase.expectedStatusCode != http.StatusOK {
+				return
+			}
+
+			bodyBytes, err := io.ReadAll(result.Body)
+			if err != nil {
+				t.Fatalf("Error while reading response body %v", err)
+			}
+			body := string(bodyBytes)
+			if body != testCase.expectedResponseBody {
+				t.Errorf("Incorrect response body: expected '%v', got '%v'", testCase.expectedResponseBody, body)
+			}
+		})
+	}
+}
+
+func TestSuccessfulOptimalSolutionsRequest(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		body                 string
+		options              solver.Options
+		solvability          solver.Solvability
+		space                solver.SolutionSpace
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:                 "Ties in lexicographic order",
+			body:                 `{"rows":2,"columns":2,"board":[1,2],"tieBreaking":"lexicographic"}`,
+			options:              solver.Options{TieBreaking: solver.LexicographicTieBreaking},
+			solvability:          solver.Solvable,
+			space:                solver.SolutionSpace{Particular: utils.BitVector{0b01_10}, Basis: []utils.BitVector{{0b11_11}}},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"hasSolution\":true,\"dimension\":1,\"solutions\":[[0,3],[1,2]]}\n",
+		},
+		{
+			name:                 "Ties by the fewest rows touched",
+			body:                 `{"rows":2,"columns":2,"board":[1,2],"tieBreaking":"fewestRows"}`,
+			options:              solver.Options{TieBreaking: solver.FewestRowsTieBreaking},
+			solvability:          solver.Solvable,
+			space:                solver.SolutionSpace{Particular: utils.BitVector{0b01_10}, Basis: []utils.BitVector{{0b11_11}, {0b01_01}}},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"hasSolution\":true,\"dimension\":2,\"solutions\":[[0,1],[2,3],[0,3],[1,2]]}\n",
+		},
+		{
+			name:                 "Unsolvable",
+			body:                 `{"rows":2,"columns":2,"board":[1,2]}`,
+			solvability:          solver.Unsolvable,
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"hasSolution\":false,\"dimension\":0,\"solutions\":[]}\n",
+		},
+		{
+			name:               "Too many solutions to search",
+			body:               `{"rows":2,"columns":2,"board":[1,2]}`,
+			solvability:        solver.Solvable,
+			space:              solver.SolutionSpace{Particular: utils.BitVector{0}, Basis: make([]utils.BitVector, solver.MaxSortedDimension+1)},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Arrage
+			enumerator := &mockEnumerator{
+				t:           t,
+				shape:       solver.Shape{RowCount: 2, ColumnCount: 2},
+				board:       utils.BitVector{0b01_10},
+				options:     testCase.options,
+				solvability: testCase.solvability,
+				space:       testCase.space,
+			}
+			api := New(nil, nil, nil, enumerator, nil)
+			handler := api.SetupHttpHandler()
+
+			request := httptest.NewRequest("POST", "/api/optimal-solutions", strings.NewReader(testCase.body))
+			response := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(response, request)
+
+			// Assert
+			result := response.Result()
+			if result.StatusCode != testCase.expectedStatusCode {
+				t.Errorf("Incorrect status code: expected %v, got %v", testCase.expectedStatusCode, result.StatusCode)
+			}
+
+			if testCase.expectedStatusCode != http.StatusOK {
+				return
+			}
+
+			bodyBytes, err := io.ReadAll(result.Body)
+			if err != nil {
+				t.Fatalf("Error while reading response body %v", err)
+			}
+			body := string(bodyBytes)
+			if body != testCase.expectedResponseBody {
+				t.Errorf("Incorrect response body: expected '%v', got '%v'", testCase.expectedResponseBody, body)
+			}
+		})
+	}
+}
+
+func TestSuccessfulQuietPatternsRequest(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		body                 string
+		shape                solver.Shape
+		patterns             []utils.BitVector
+		expectedResponseBody string
+	}{
+		{
+			name:                 "No quiet patterns",
+			body:                 `{"rows":3,"columns":3}`,
+			shape:                solver.Shape{RowCount: 3, ColumnCount: 3},
+			patterns:             nil,
+			expectedResponseBody: "{\"patterns\":[]}\n",
+		},
+		{
+			name:                 "Quiet patterns",
+			body:                 `{"rows":2,"columns":3,"topology":"toroidal"}`,
+			shape:                solver.Shape{RowCount: 2, ColumnCount: 3, Topology: solver.ToroidalTopology},
+			patterns:             []utils.BitVector{{0b011_011}, {0b110_000}},
+			expectedResponseBody: "{\"patterns\":[{\"cells\":[0,1,3,4],\"grid\":[\"XX.\",\"XX.\"]},{\"cells\":[4,5],\"grid\":[\"...\",\".XX\"]}]}\n",
+		},
+		{
+			name:                 "Quiet pattern on a masked board",
+			body:                 `{"rows":2,"columns":2,"mask":[0,1,3]}`,
+			shape:                solver.Shape{RowCount: 2, ColumnCount: 2, Mask: utils.BitVector{0b10_11}},
+			patterns:             []utils.BitVector{{0b10_01}},
+			expectedResponseBody: "{\"patterns\":[{\"cells\":[0,3],\"grid\":[\"X.\",\" X\"]}]}\n",
+		},
+		{
+			name:                 "Quiet pattern on a hexagonal grid",
+			body:                 `{"rows":2,"columns":2,"grid":"hexagonal"}`,
+			shape:                solver.Shape{RowCount: 2, ColumnCount: 2, Grid: solver.HexagonalGrid},
+			patterns:             []utils.BitVector{{0b11_01}},
+			expectedResponseBody: "{\"patterns\":[{\"cells\":[0,2,3],\"grid\":[\"X .\",\" X X\"]}]}\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Arrage
+			enumerator := &mockEnumerator{t: t, shape: testCase.shape, patterns: testCase.patterns}
+			api := New(nil, nil, nil, enumerator, nil)
+			handler := api.SetupHttpHandler()
+
+			request := httptest.NewRequest("POST", "/api/quiet-patterns", strings.NewReader(testCase.body))
+			response := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(response, request)
+
+			// Assert
+			result := response.Result()
+			if result.StatusCode != http.StatusOK {
+				t.Errorf("Incorrect status code: expected %v, got %v", http.StatusOK, result.StatusCode)
+			}
+
+			bodyBytes, err := io.ReadAll(result.Body)
+			if err != nil {
+				t.Fatalf("Error while reading response body %v", err)
+			}
+			body := string(bodyBytes)
+			if body != testCase.expectedResponseBody {
+				t.Errorf("Incorrect response body: expected '%v', got '%v'", testCase.expectedResponseBody, body)
+			}
+		})
+	}
+}
+
+func TestSuccessfulNearestSolvableBoardRequest(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		body                 string
+		shape                solver.Shape
+		board                utils.BitVector
+		options              solver.Options
+		corrections          utils.BitVector
+		solution             utils.BitVector
+		err                  error
+		notProvenOptimal     bool
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:                 "Board with a single mistake",
+			body:                 `{"rows":5,"columns":5,"board":[0]}`,
+			shape:                solver.DefaultShape,
+			board:                utils.BitVector{0b00000_00000_00000_00000_00001},
+			corrections:          utils.BitVector{0b00000_00000_00000_00000_00010},
+			solution:             utils.BitVector{0b00000_00000_00000_00000_00011},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"corrections\":[1],\"solution\":[0,1]}\n",
+		},
+		{
+			name:                 "Solvable board",
+			body:                 `{"rows":3,"columns":3,"board":[1,3,4,5,7]}`,
+			shape:                solver.Shape{RowCount: 3, ColumnCount: 3},
+			board:                utils.BitVector{0b010_111_010},
+			corrections:          utils.BitVector{0b000_000_000},
+			solution:             utils.BitVector{0b000_010_000},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"corrections\":[],\"solution\":[4]}\n",
+		},
+		{
+			name:                 "Board with constraints",
+			body:                 `{"rows":3,"columns":3,"board":[1,3,4,5,7],"forbidden":[4]}`,
+			shape:                solver.Shape{RowCount: 3, ColumnCount: 3},
+			board:                utils.BitVector{0b010_111_010},
+			options:              solver.Options{ForbiddenClicks: utils.BitVector{0b000_010_000}},
+			corrections:          utils.BitVector{0b000_010_000},
+			solution:             utils.BitVector{0b010_000_010},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"corrections\":[4],\"solution\":[1,7]}\n",
+		},
+		{
+			name:                 "Corrected board with a solution not proven optimal in time",
+			body:                 `{"rows":3,"columns":3,"board":[1,3,4,5,7]}`,
+			shape:                solver.Shape{RowCount: 3, ColumnCount: 3},
+			board:                utils.BitVector{0b010_111_010},
+			corrections:          utils.BitVector{0b000_000_000},
+			solution:             utils.BitVector{0b101_000_101},
+			notProvenOptimal:     true,
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"corrections\":[],\"solution\":[0,2,6,8],\"notProvenOptimal\":true}\n",
+		},
+		{
+			name:               "Too many parity checks",
+			body:               `{"rows":3,"columns":3,"board":[0]}`,
+			shape:              solver.Shape{RowCount: 3, ColumnCount: 3},
+			board:              utils.BitVector{0b000_000_001},
+			err:                fmt.Errorf("cannot correct the board: %w", solver.ErrTooManyParityChecks),
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Corrected board without solution",
+			body:               `{"rows":3,"columns":3,"board":[0]}`,
+			shape:              solver.Shape{RowCount: 3, ColumnCount: 3},
+			board:              utils.BitVector{0b000_000_001},
+			err:                errors.New("the corrected board cannot be solved"),
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Arrage
+			corrector := &mockCorrector{
+				t:                t,
+				shape:            testCase.shape,
+				board:            testCase.board,
+				options:          testCase.options,
+				corrections:      testCase.corrections,
+				solution:         testCase.solution,
+				err:              testCase.err,
+				notProvenOptimal: testCase.notProvenOptimal,
+			}
+			api := New(nil, nil, nil, nil, corrector)
+			handler := api.SetupHttpHandler()
+
+			request := httptest.NewRequest("POST", "/api/nearest-solvable-board", strings.NewReader(testCase.body))
+			response := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(response, request)
+
+			// Assert
+			result := response.Result()
+			if result.StatusCode != testCase.expectedStatusCode {
+				t.Errorf("Incorrect status code: expected %v, got %v", testCase.expectedStatusCode, result.StatusCode)
+			}
+
+			if testCase.expectedStatusCode != http.StatusOK {
+				return
+			}
+
+			bodyBytes, err := io.ReadAll(result.Body)
+			if err != nil {
+				t.Fatalf("Error while reading response body %v", err)
+			}
+			body := string(bodyBytes)
+			if body != testCase.expectedResponseBody {
+				t.Errorf("Incorrect response body: expected '%v', got '%v'", testCase.expectedResponseBody, body)
+			}
+		})
+	}
+}
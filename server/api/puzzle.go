@@ -0,0 +1,329 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"server/solver"
+	"server/utils"
+)
+
+// The upper limit on the size of the request body
+const maxPuzzleSize = 1 << 20
+
+type offset struct {
+	Row    int `json:"row"`
+	Column int `json:"column"`
+}
+
+// The description of the board shape, shared by all kinds of puzzles
+type shapeDescription struct {
+	Rows          int      `json:"rows"`
+	Columns       int      `json:"columns"`
+	Topology      string   `json:"topology"`
+	Grid          string   `json:"grid"`
+	Neighbourhood string   `json:"neighbourhood"`
+	Offsets       []offset `json:"offsets"`
+	Toggles       [][]int  `json:"toggles"`
+	// The indexes of the cells that exist on the board, all cells exist if not given
+	Mask []int `json:"mask"`
+}
+
+type puzzle struct {
+	shapeDescription
+	// The indexes of the cells that are lit
+	Board []int `json:"board"`
+	// The indexes of the cells that have to be lit in the end, all lights have to be turned off if not given
+	Target []int `json:"target"`
+	// The indexes of the cells that have to match the target, all of them have to if not given
+	TargetMask []int `json:"targetMask"`
+	// The cost of clicking the cells by their indexes, the cells not listed cost 1
+	Costs map[int]int `json:"costs"`
+	// The indexes of the cells that must not be clicked
+	Forbidden []int `json:"forbidden"`
+	// The indexes of the cells that must be clicked
+	Mandatory []int `json:"mandatory"`
+	// The rule that chooses between the solutions with the same lowest cost
+	TieBreaking string `json:"tieBreaking"`
+}
+
+type modularPuzzle struct {
+	shapeDescription
+	States int `json:"states"`
+	// The state of each cell
+	Board []int `json:"board"`
+}
+
+type graphPuzzle struct {
+	// The graph is either given by the number of vertices and the list of edges, or by the neighbours of each vertex
+	Vertices   int     `json:"vertices"`
+	Edges      [][]int `json:"edges"`
+	Neighbours [][]int `json:"neighbours"`
+	// The indexes of the vertices that are lit
+	Lights []int `json:"lights"`
+}
+
+func parsePuzzle(w http.ResponseWriter, r *http.Request) (solver.Shape, utils.BitVector, solver.Options, error) {
+	var puzzle puzzle
+	if err := decodePuzzle(w, r, &puzzle); err != nil {
+		return solver.Shape{}, nil, solver.Options{}, err
+	}
+
+	shape, err := createShape(&puzzle.shapeDescription)
+	if err != nil {
+		return solver.Shape{}, nil, solver.Options{}, err
+	}
+
+	board, err := createCellSet(puzzle.Board, shape.CellCount())
+	if err != nil {
+		return solver.Shape{}, nil, solver.Options{}, err
+	}
+
+	for _, cell := range puzzle.Board {
+		if !shape.HasCell(cell) {
+			return solver.Shape{}, nil, solver.Options{}, fmt.Errorf("the lit cell %v is missing from the board", cell)
+		}
+	}
+
+	options, err := createOptions(&puzzle, shape)
+	if err != nil {
+		return solver.Shape{}, nil, solver.Options{}, err
+	}
+
+	return shape, board, options, nil
+}
+
+func createOptions(puzzle *puzzle, shape solver.Shape) (solver.Options, error) {
+	var options solver.Options
+
+	if puzzle.Target != nil {
+		target, err := createCellSet(puzzle.Target, shape.CellCount())
+		if err != nil {
+			return options, err
+		}
+
+		options.Target = target
+	}
+
+	if puzzle.TargetMask != nil {
+		targetMask, err := createCellSet(puzzle.TargetMask, shape.CellCount())
+		if err != nil {
+			return options, err
+		}
+
+		options.TargetMask = targetMask
+	}
+
+	if puzzle.Costs != nil {
+		costs, err := createCosts(puzzle.Costs, shape)
+		if err != nil {
+			return options, err
+		}
+
+		options.Costs = costs
+	}
+
+	if puzzle.Forbidden != nil {
+		forbiddenClicks, err := createCellSet(puzzle.Forbidden, shape.CellCount())
+		if err != nil {
+			return options, err
+		}
+
+		options.ForbiddenClicks = forbiddenClicks
+	}
+
+	if puzzle.Mandatory != nil {
+		mandatoryClicks, err := createCellSet(puzzle.Mandatory, shape.CellCount())
+		if err != nil {
+			return options, err
+		}
+
+		options.MandatoryClicks = mandatoryClicks
+	}
+
+	tieBreaking, err := getTieBreaking(puzzle.TieBreaking)
+	if err != nil {
+		return options, err
+	}
+	options.TieBreaking = tieBreaking
+
+	return options, options.Validate(shape)
+}
+
+func parseShapeDescription(w http.ResponseWriter, r *http.Request) (solver.Shape, error) {
+	var description shapeDescription
+	if err := decodePuzzle(w, r, &description); err != nil {
+		return solver.Shape{}, err
+	}
+
+	return createShape(&description)
+}
+
+func parseModularPuzzle(w http.ResponseWriter, r *http.Request) (solver.Shape, []int, int, error) {
+	var puzzle modularPuzzle
+	if err := decodePuzzle(w, r, &puzzle); err != nil {
+		return solver.Shape{}, nil, 0, err
+	}
+
+	if err := solver.ValidateStateCount(puzzle.States); err != nil {
+		return solver.Shape{}, nil, 0, err
+	}
+
+	shape, err := createShape(&puzzle.shapeDescription)
+	if err != nil {
+		return solver.Shape{}, nil, 0, err
+	}
+
+	if len(puzzle.Board) != shape.CellCount() {
+		return solver.Shape{}, nil, 0, fmt.Errorf("the board has to contain the state of each of the %v cells", shape.CellCount())
+	}
+
+	for cell, state := range puzzle.Board {
+		if state < 0 || state >= puzzle.States {
+			return solver.Shape{}, nil, 0, fmt.Errorf("invalid cell state %v", state)
+		}
+
+		if state != 0 && !shape.HasCell(cell) {
+			return solver.Shape{}, nil, 0, fmt.Errorf("the missing cell %v has a non-zero state", cell)
+		}
+	}
+
+	return shape, puzzle.Board, puzzle.States, nil
+}
+
+func parseGraphPuzzle(w http.ResponseWriter, r *http.Request) (solver.Graph, utils.BitVector, error) {
+	var puzzle graphPuzzle
+	if err := decodePuzzle(w, r, &puzzle); err != nil {
+		return solver.Graph{}, nil, err
+	}
+
+	graph, err := createGraph(&puzzle)
+	if err != nil {
+		return solver.Graph{}, nil, err
+	}
+
+	if err := graph.Validate(); err != nil {
+		return solver.Graph{}, nil, err
+	}
+
+	lights, err := createCellSet(puzzle.Lights, graph.VertexCount())
+	if err != nil {
+		return solver.Graph{}, nil, err
+	}
+
+	return graph, lights, nil
+}
+
+func decodePuzzle(w http.ResponseWriter, r *http.Request, puzzle interface{}) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxPuzzleSize))
+	decoder.DisallowUnknownFields()
+
+	return decoder.Decode(puzzle)
+}
+
+func createShape(puzzle *shapeDescription) (solver.Shape, error) {
+	shape := solver.Shape{RowCount: puzzle.Rows, ColumnCount: puzzle.Columns}
+	if err := shape.Validate(); err != nil {
+		return shape, err
+	}
+
+	topology, err := getTopology(puzzle.Topology)
+	if err != nil {
+		return shape, err
+	}
+	shape.Topology = topology
+
+	shape.Grid, err = getGrid(puzzle.Grid)
+	if err != nil {
+		return shape, err
+	}
+
+	if puzzle.Neighbourhood != "" && puzzle.Offsets != nil {
+		return shape, errors.New("the neighbourhood and the offsets cannot be given at the same time")
+	}
+
+	shape.Neighbourhood, err = getNeighbourhood(puzzle.Neighbourhood, shape.RowCount, shape.ColumnCount)
+	if err != nil {
+		return shape, err
+	}
+
+	for _, offset := range puzzle.Offsets {
+		shape.Neighbourhood = append(shape.Neighbourhood, solver.Offset{Row: offset.Row, Column: offset.Column})
+	}
+
+	for _, toggledCells := range puzzle.Toggles {
+		flipVector, err := createCellSet(toggledCells, shape.CellCount())
+		if err != nil {
+			return shape, err
+		}
+
+		shape.FlipVectors = append(shape.FlipVectors, flipVector)
+	}
+
+	if puzzle.Mask != nil {
+		shape.Mask, err = createCellSet(puzzle.Mask, shape.CellCount())
+		if err != nil {
+			return shape, err
+		}
+	}
+
+	return shape, shape.Validate()
+}
+
+func createGraph(puzzle *graphPuzzle) (solver.Graph, error) {
+	if puzzle.Neighbours != nil {
+		if puzzle.Edges != nil {
+			return solver.Graph{}, errors.New("the edges and the neighbours cannot be given at the same time")
+		}
+
+		if puzzle.Vertices != 0 && puzzle.Vertices != len(puzzle.Neighbours) {
+			return solver.Graph{}, errors.New("the number of vertices does not match the neighbours")
+		}
+
+		return solver.Graph{Neighbours: puzzle.Neighbours}, nil
+	}
+
+	edges := make([]solver.Edge, 0, len(puzzle.Edges))
+	for _, edge := range puzzle.Edges {
+		if len(edge) != 2 {
+			return solver.Graph{}, fmt.Errorf("invalid edge %v", edge)
+		}
+
+		edges = append(edges, solver.Edge{From: edge[0], To: edge[1]})
+	}
+
+	return solver.NewGraphFromEdges(puzzle.Vertices, edges)
+}
+
+// Creates the cost of each cell from the costs of the listed cells
+func createCosts(costMap map[int]int, shape solver.Shape) ([]int, error) {
+	costs := make([]int, shape.CellCount())
+	for i := range costs {
+		costs[i] = 1
+	}
+
+	for cell, cost := range costMap {
+		if cell < 0 || cell >= len(costs) || !shape.HasCell(cell) {
+			return nil, fmt.Errorf("invalid cell index %v", cell)
+		}
+
+		costs[cell] = cost
+	}
+
+	return costs, nil
+}
+
+// Creates a bit vector from the list of cell indexes
+func createCellSet(cells []int, cellCount int) (utils.BitVector, error) {
+	cellSet := utils.NewBitVector(cellCount)
+	for _, cell := range cells {
+		if cell < 0 || cell >= cellCount {
+			return nil, fmt.Errorf("invalid cell index %v", cell)
+		}
+
+		cellSet.SetBit(cell)
+	}
+
+	return cellSet, nil
+}
@@ -5,32 +5,173 @@ import (
 	"net/http"
 	"server/solver"
 	"server/utils"
+	"strings"
 )
 
 type solution struct {
 	HasSolution bool  `json:"hasSolution"`
 	Solution    []int `json:"solution"`
+	// Set when the board could only be solved without the forbidden and mandatory clicks
+	UnsolvableUnderConstraints bool `json:"unsolvableUnderConstraints,omitempty"`
+	// Set when the time was up before the solution could be proven to have the fewest clicks, so it is only the best one found
+	NotProvenOptimal bool `json:"notProvenOptimal,omitempty"`
+	// The proof of the board being unsolvable
+	Certificate *certificate `json:"certificate,omitempty"`
 }
 
-func writeSolution(w http.ResponseWriter, solvable bool, solutionNumber uint32) {
-	solution := createSolution(solvable, solutionNumber)
+// Parity checks are sets of cells, where every allowed click toggles an even number of the cells,
+// so if an odd number of them differ from their target, the board cannot be solved
+type certificate struct {
+	// The first failing check, which is only a quiet pattern if the clicks toggle each other symmetrically
+	Witness       []int   `json:"witness"`
+	FailingChecks [][]int `json:"failingChecks"`
+}
+
+// A page of all the solutions of a board
+type solutionPage struct {
+	HasSolution                bool `json:"hasSolution"`
+	UnsolvableUnderConstraints bool `json:"unsolvableUnderConstraints,omitempty"`
+	// The number of independent ways to change a solution, there are 2 to the power of it solutions
+	Dimension int     `json:"dimension"`
+	Solutions [][]int `json:"solutions"`
+}
+
+// The nearest solvable board, given by the cells to toggle on the original board, and the solution after toggling them
+type correction struct {
+	Corrections []int `json:"corrections"`
+	Solution    []int `json:"solution"`
+	// Set when the time was up before the solution of the corrected board could be proven to have the fewest clicks
+	NotProvenOptimal bool `json:"notProvenOptimal,omitempty"`
+}
+
+type quietPattern struct {
+	Cells []int `json:"cells"`
+	// The rows of the board, where "X" marks the clicked cells, "." the other cells, and " " the missing ones
+	Grid []string `json:"grid"`
+}
+
+type quietPatterns struct {
+	Patterns []quietPattern `json:"patterns"`
+}
+
+type modularSolution struct {
+	HasSolution bool `json:"hasSolution"`
+	// The number of clicks needed on each cell
+	Clicks []int `json:"clicks"`
+}
+
+func writeSolution(w http.ResponseWriter, shape solver.Shape, solvability solver.Solvability, clicks utils.BitVector, optimal bool) {
+	writeSolutionWithCertificate(w, shape, solvability, clicks, optimal, solver.Certificate{})
+}
+
+func writeSolutionWithCertificate(w http.ResponseWriter, shape solver.Shape, solvability solver.Solvability, clicks utils.BitVector, optimal bool, proof solver.Certificate) {
+	solution := createSolution(shape, solvability, clicks, optimal)
+	if len(proof.FailingChecks) > 0 {
+		solution.Certificate = createCertificate(shape, proof)
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(solution)
 }
 
-func createSolution(solvable bool, solutionNumber uint32) solution {
-	if !solvable {
-		return solution{false, nil}
+func writeModularSolution(w http.ResponseWriter, solvable bool, clicks []int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(modularSolution{solvable, clicks})
+}
+
+func writeSolutionPage(w http.ResponseWriter, shape solver.Shape, solvability solver.Solvability, dimension int, solutions []utils.BitVector) {
+	page := solutionPage{
+		HasSolution:                solvability == solver.Solvable,
+		UnsolvableUnderConstraints: solvability == solver.UnsolvableUnderConstraints,
+		Dimension:                  dimension,
+		Solutions:                  make([][]int, 0, len(solutions)),
 	}
 
+	for _, clicks := range solutions {
+		page.Solutions = append(page.Solutions, getClickedCells(shape, clicks))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(page)
+}
+
+func writeQuietPatterns(w http.ResponseWriter, shape solver.Shape, patterns []utils.BitVector) {
+	response := quietPatterns{Patterns: make([]quietPattern, 0, len(patterns))}
+	for _, pattern := range patterns {
+		response.Patterns = append(response.Patterns, quietPattern{getClickedCells(shape, pattern), renderGrid(shape, pattern)})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+func writeCorrection(w http.ResponseWriter, shape solver.Shape, corrections utils.BitVector, solution utils.BitVector, optimal bool) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(correction{getClickedCells(shape, corrections), getClickedCells(shape, solution), !optimal})
+}
+
+func createCertificate(shape solver.Shape, proof solver.Certificate) *certificate {
+	failingChecks := make([][]int, 0, len(proof.FailingChecks))
+	for _, check := range proof.FailingChecks {
+		failingChecks = append(failingChecks, getClickedCells(shape, check))
+	}
+
+	return &certificate{Witness: getClickedCells(shape, proof.Witness()), FailingChecks: failingChecks}
+}
+
+func createSolution(shape solver.Shape, solvability solver.Solvability, clicks utils.BitVector, optimal bool) solution {
+	if solvability != solver.Solvable {
+		return solution{false, nil, solvability == solver.UnsolvableUnderConstraints, false, nil}
+	}
+
+	return solution{true, getClickedCells(shape, clicks), false, !optimal, nil}
+}
+
+func getClickedCells(shape solver.Shape, clicks utils.BitVector) []int {
 	indexes := make([]int, 0)
-	for i := uint8(0); i < solver.MatrixSize; i++ {
-		if utils.TestBit(solutionNumber, i) {
-			indexes = append(indexes, int(i))
+	for i := 0; i < shape.CellCount(); i++ {
+		if clicks.TestBit(i) {
+			indexes = append(indexes, i)
 		}
 	}
 
-	return solution{true, indexes}
+	return indexes
+}
+
+// Renders the clicked cells as the rows of the board, on a hexagonal grid the cells are separated
+// by spaces, so the odd rows can be shifted by half a cell
+func renderGrid(shape solver.Shape, clicks utils.BitVector) []string {
+	cells := utils.NewBitVector(shape.CellCount())
+	for _, cell := range shape.Cells() {
+		cells.SetBit(cell)
+	}
+
+	rows := make([]string, shape.RowCount)
+	for row := range rows {
+		var builder strings.Builder
+		for column := 0; column < shape.ColumnCount; column++ {
+			if shape.Grid == solver.HexagonalGrid && (column > 0 || row%2 == 1) {
+				builder.WriteByte(' ')
+			}
+
+			cell := row*shape.ColumnCount + column
+			switch {
+			case !cells.TestBit(cell):
+				builder.WriteByte(' ')
+			case clicks.TestBit(cell):
+				builder.WriteByte('X')
+			default:
+				builder.WriteByte('.')
+			}
+		}
+
+		rows[row] = builder.String()
+	}
+
+	return rows
 }
@@ -0,0 +1,56 @@
+// Command tablegen builds the solution table of every board of a rectangular shape, which the server can load with $SOLUTION_TABLE
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"runtime"
+	"server/solver"
+	"time"
+)
+
+func main() {
+	output := flag.String("output", "solutions.table", "the path of the table file to write")
+	rowCount := flag.Int("rows", solver.DefaultShape.RowCount, "the number of rows of the board")
+	columnCount := flag.Int("columns", solver.DefaultShape.ColumnCount, "the number of columns of the board")
+	workerCount := flag.Int("workers", runtime.NumCPU(), "the number of boards solved at the same time")
+	flag.Parse()
+
+	shape := solver.Shape{RowCount: *rowCount, ColumnCount: *columnCount}
+
+	// The boards are solved by the same solver as the one the server uses
+	optimizer := solver.NewBruteForceOptimizer()
+	freeVariableFixer := solver.NewFreeVariableFixer(optimizer)
+	boardSolver := solver.NewPrecomputedSolver(freeVariableFixer)
+
+	log.Printf("Building the solution table of the %vx%v board with %v workers", shape.RowCount, shape.ColumnCount, *workerCount)
+	start := time.Now()
+
+	table, err := solver.BuildSolutionTable(shape, boardSolver, *workerCount)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := table.Verify(); err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("Built and verified the solution table in %v", time.Since(start))
+
+	file, err := os.Create(*output)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if _, err := table.WriteTo(file); err != nil {
+		file.Close()
+		log.Fatal(err)
+	}
+
+	if err := file.Close(); err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("Wrote the solution table to %v", *output)
+}
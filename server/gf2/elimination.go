@@ -0,0 +1,55 @@
+package gf2
+
+import (
+	"server/utils"
+)
+
+// TransformToRowEchelon brings the rows to row echelon form in place, choosing pivots only from
+// the first columnCount columns (so the rest can be used e.g. as the constant column of an
+// augmented matrix). The pivot of a row is its lowest set bit. Returns the number of pivot rows.
+func TransformToRowEchelon(rows []utils.BitVector, columnCount int) int {
+	i := 0
+	j := 0
+
+	for i < len(rows) && j < columnCount {
+		if !rows[i].TestBit(j) && !swapPivot(rows, i, j) {
+			j++
+			continue
+		}
+
+		for t := i + 1; t < len(rows); t++ {
+			if rows[t].TestBit(j) {
+				rows[t].Xor(rows[i])
+			}
+		}
+
+		i++
+		j++
+	}
+
+	return i
+}
+
+func swapPivot(rows []utils.BitVector, i, j int) bool {
+	for t := i + 1; t < len(rows); t++ {
+		if rows[t].TestBit(j) {
+			rows[i], rows[t] = rows[t], rows[i]
+			return true
+		}
+	}
+
+	return false
+}
+
+// BackSubstitution brings rows that are already in row echelon form to reduced row echelon form in place
+func BackSubstitution(rows []utils.BitVector, rank int) {
+	for i := rank - 1; i >= 0; i-- {
+		pivotColumn := rows[i].TrailingZeros()
+
+		for t := 0; t < i; t++ {
+			if rows[t].TestBit(pivotColumn) {
+				rows[t].Xor(rows[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package gf2
+
+import (
+	"reflect"
+	"server/utils"
+	"testing"
+)
+
+func TestTransformToRowEchelon(t *testing.T) {
+	testCases := []struct {
+		name           string
+		rows           []utils.BitVector
+		columnCount    int
+		expectedRank   int
+		expectedResult []utils.BitVector
+	}{
+		{
+			name: "Empty matrix",
+			rows: []utils.BitVector{
+				{0b000},
+				{0b000},
+				{0b000},
+			},
+			columnCount:  3,
+			expectedRank: 0,
+			expectedResult: []utils.BitVector{
+				{0b000},
+				{0b000},
+				{0b000},
+			},
+		},
+		{
+			name: "Scrambled identity matrix",
+			rows: []utils.BitVector{
+				{0b0100},
+				{0b0001},
+				{0b1000},
+				{0b0010},
+			},
+			columnCount:  4,
+			expectedRank: 4,
+			expectedResult: []utils.BitVector{
+				{0b0001},
+				{0b0010},
+				{0b0100},
+				{0b1000},
+			},
+		},
+		{
+			name: "Singular matrix",
+			rows: []utils.BitVector{
+				{0b0110},
+				{0b1011},
+				{0b1101},
+				{0b0110},
+			},
+			columnCount:  4,
+			expectedRank: 2,
+			expectedResult: []utils.BitVector{
+				{0b1011},
+				{0b0110},
+				{0b0000},
+				{0b0000},
+			},
+		},
+		{
+			name: "Pivots restricted to the coefficient columns",
+			rows: []utils.BitVector{
+				{0b1_011},
+				{0b0_011},
+				{0b1_000},
+			},
+			columnCount:  3,
+			expectedRank: 1,
+			expectedResult: []utils.BitVector{
+				{0b1_011},
+				{0b1_000},
+				{0b1_000},
+			},
+		},
+		{
+			name: "More rows than columns",
+			rows: []utils.BitVector{
+				{0b10},
+				{0b11},
+				{0b01},
+			},
+			columnCount:  2,
+			expectedRank: 2,
+			expectedResult: []utils.BitVector{
+				{0b11},
+				{0b10},
+				{0b00},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Act
+			rank := TransformToRowEchelon(testCase.rows, testCase.columnCount)
+
+			// Assert
+			if rank != testCase.expectedRank {
+				t.Errorf("Incorrect result for rank: expected %v, got %v", testCase.expectedRank, rank)
+			}
+
+			if !reflect.DeepEqual(testCase.expectedResult, testCase.rows) {
+				t.Errorf("Incorrect result for the rows: expected %b, got %b", testCase.expectedResult, testCase.rows)
+			}
+		})
+	}
+}
+
+func TestBackSubstitution(t *testing.T) {
+	testCases := []struct {
+		name           string
+		rows           []utils.BitVector
+		rank           int
+		expectedResult []utils.BitVector
+	}{
+		{
+			name: "Triangular matrix",
+			rows: []utils.BitVector{
+				{0b111},
+				{0b110},
+				{0b100},
+			},
+			rank: 3,
+			expectedResult: []utils.BitVector{
+				{0b001},
+				{0b010},
+				{0b100},
+			},
+		},
+		{
+			name: "Singular matrix",
+			rows: []utils.BitVector{
+				{0b1011},
+				{0b0110},
+				{0b0000},
+				{0b0000},
+			},
+			rank: 2,
+			expectedResult: []utils.BitVector{
+				{0b1101},
+				{0b0110},
+				{0b0000},
+				{0b0000},
+			},
+		},
+		{
+			name: "Augmented matrix",
+			rows: []utils.BitVector{
+				{0b1_011},
+				{0b0_110},
+				{0b1_100},
+			},
+			rank: 3,
+			expectedResult: []utils.BitVector{
+				{0b0_001},
+				{0b1_010},
+				{0b1_100},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Act
+			BackSubstitution(testCase.rows, testCase.rank)
+
+			// Assert
+			if !reflect.DeepEqual(testCase.expectedResult, testCase.rows) {
+				t.Errorf("Incorrect result: expected %b, got %b", testCase.expectedResult, testCase.rows)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package gf2
+
+import (
+	"server/utils"
+)
+
+// Matrix is a matrix over GF(2), storing each of its rows in a bit vector
+type Matrix struct {
+	Rows        []utils.BitVector
+	ColumnCount int
+}
+
+func NewMatrix(rowCount, columnCount int) Matrix {
+	rows := make([]utils.BitVector, rowCount)
+	for i := range rows {
+		rows[i] = utils.NewBitVector(columnCount)
+	}
+
+	return Matrix{Rows: rows, ColumnCount: columnCount}
+}
+
+func NewIdentityMatrix(size int) Matrix {
+	matrix := NewMatrix(size, size)
+	for i := 0; i < size; i++ {
+		matrix.Rows[i].SetBit(i)
+	}
+
+	return matrix
+}
+
+func (m Matrix) RowCount() int {
+	return len(m.Rows)
+}
+
+func (m Matrix) Clone() Matrix {
+	return m.withExtraColumns(0)
+}
+
+// Creates a copy of the matrix, with enough capacity in the rows to hold the extra columns as well
+func (m Matrix) withExtraColumns(extraColumnCount int) Matrix {
+	clone := NewMatrix(m.RowCount(), m.ColumnCount+extraColumnCount)
+	for i, row := range m.Rows {
+		clone.Rows[i].Xor(row)
+	}
+	clone.ColumnCount = m.ColumnCount
+
+	return clone
+}
+
+func (m Matrix) Equal(other Matrix) bool {
+	if m.RowCount() != other.RowCount() || m.ColumnCount != other.ColumnCount {
+		return false
+	}
+
+	for i := range m.Rows {
+		if !m.Rows[i].Equal(other.Rows[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (m Matrix) Transpose() Matrix {
+	transposed := NewMatrix(m.ColumnCount, m.RowCount())
+	for i, row := range m.Rows {
+		for j := 0; j < m.ColumnCount; j++ {
+			if row.TestBit(j) {
+				transposed.Rows[j].SetBit(i)
+			}
+		}
+	}
+
+	return transposed
+}
+
+// RowEchelon returns the row echelon form of the matrix and its rank
+func (m Matrix) RowEchelon() (Matrix, int) {
+	echelon := m.Clone()
+	rank := TransformToRowEchelon(echelon.Rows, echelon.ColumnCount)
+
+	return echelon, rank
+}
+
+// ReducedRowEchelon returns the reduced row echelon form of the matrix and its rank
+func (m Matrix) ReducedRowEchelon() (Matrix, int) {
+	echelon, rank := m.RowEchelon()
+	BackSubstitution(echelon.Rows, rank)
+
+	return echelon, rank
+}
+
+func (m Matrix) Rank() int {
+	_, rank := m.RowEchelon()
+	return rank
+}
+
+// Inverse returns the inverse of the matrix, or false if it is not invertible
+func (m Matrix) Inverse() (Matrix, bool) {
+	size := m.RowCount()
+	if size != m.ColumnCount {
+		return Matrix{}, false
+	}
+
+	// Eliminate the matrix augmented with the identity matrix
+	augmented := m.withExtraColumns(size)
+	for i := 0; i < size; i++ {
+		augmented.Rows[i].SetBit(size + i)
+	}
+
+	rank := TransformToRowEchelon(augmented.Rows, size)
+	if rank < size {
+		return Matrix{}, false
+	}
+
+	BackSubstitution(augmented.Rows, rank)
+
+	// The left side is now the identity matrix, and the right side is the inverse
+	inverse := NewMatrix(size, size)
+	for i, row := range augmented.Rows {
+		for j := 0; j < size; j++ {
+			if row.TestBit(size + j) {
+				inverse.Rows[i].SetBit(j)
+			}
+		}
+	}
+
+	return inverse, true
+}
+
+// NullSpace returns a basis of the vectors x for which Mx = 0, with one vector for each free variable
+func (m Matrix) NullSpace() []utils.BitVector {
+	echelon, rank := m.ReducedRowEchelon()
+
+	// Find the pivot column of each row and the free columns
+	pivotColumns := make([]int, rank)
+	isPivotColumn := make([]bool, m.ColumnCount)
+	for i := 0; i < rank; i++ {
+		pivotColumns[i] = echelon.Rows[i].TrailingZeros()
+		isPivotColumn[pivotColumns[i]] = true
+	}
+
+	// Each free variable set to 1 determines the values of the pivot variables
+	basis := make([]utils.BitVector, 0, m.ColumnCount-rank)
+	for j := 0; j < m.ColumnCount; j++ {
+		if isPivotColumn[j] {
+			continue
+		}
+
+		vector := utils.NewBitVector(m.ColumnCount)
+		vector.SetBit(j)
+		for i := 0; i < rank; i++ {
+			if echelon.Rows[i].TestBit(j) {
+				vector.SetBit(pivotColumns[i])
+			}
+		}
+
+		basis = append(basis, vector)
+	}
+
+	return basis
+}
+
+// MulVector returns the product Mv
+func (m Matrix) MulVector(vector utils.BitVector) utils.BitVector {
+	result := utils.NewBitVector(m.RowCount())
+	for i, row := range m.Rows {
+		if row.Dot(vector) {
+			result.SetBit(i)
+		}
+	}
+
+	return result
+}
+
+// Mul returns the product MN, the column count of M has to match the row count of N
+func (m Matrix) Mul(other Matrix) Matrix {
+	result := NewMatrix(m.RowCount(), other.ColumnCount)
+	for i, row := range m.Rows {
+		for j, otherRow := range other.Rows {
+			if row.TestBit(j) {
+				result.Rows[i].Xor(otherRow)
+			}
+		}
+	}
+
+	return result
+}
+
+// Solve returns a solution of Mx = b with all the free variables set to 0, or false if there is none
+func (m Matrix) Solve(constants utils.BitVector) (utils.BitVector, bool) {
+	constantColumn := m.ColumnCount
+
+	// Eliminate the augmented matrix
+	augmented := m.withExtraColumns(1)
+	for i := range augmented.Rows {
+		if constants.TestBit(i) {
+			augmented.Rows[i].SetBit(constantColumn)
+		}
+	}
+
+	rank := TransformToRowEchelon(augmented.Rows, m.ColumnCount)
+	for _, row := range augmented.Rows[rank:] {
+		if !row.IsZero() {
+			return nil, false
+		}
+	}
+
+	BackSubstitution(augmented.Rows, rank)
+
+	// Read the values of the pivot variables from the constant column
+	solution := utils.NewBitVector(m.ColumnCount)
+	for _, row := range augmented.Rows[:rank] {
+		if row.TestBit(constantColumn) {
+			solution.SetBit(row.TrailingZeros())
+		}
+	}
+
+	return solution, true
+}
@@ -0,0 +1,268 @@
+package gf2
+
+import (
+	"server/utils"
+	"testing"
+)
+
+// A 4x4 matrix with rank 2, used by multiple tests
+var singularMatrix = Matrix{
+	Rows: []utils.BitVector{
+		{0b0110},
+		{0b1011},
+		{0b1101},
+		{0b0110},
+	},
+	ColumnCount: 4,
+}
+
+// A 3x3 invertible matrix, used by multiple tests
+var invertibleMatrix = Matrix{
+	Rows: []utils.BitVector{
+		{0b011},
+		{0b110},
+		{0b111},
+	},
+	ColumnCount: 3,
+}
+
+func TestEchelonForms(t *testing.T) {
+	// Act
+	echelon, echelonRank := singularMatrix.RowEchelon()
+	reduced, reducedRank := singularMatrix.ReducedRowEchelon()
+	rank := singularMatrix.Rank()
+
+	// Assert
+	expectedEchelon := Matrix{Rows: []utils.BitVector{{0b1011}, {0b0110}, {0b0000}, {0b0000}}, ColumnCount: 4}
+	if !echelon.Equal(expectedEchelon) || echelonRank != 2 {
+		t.Errorf("Incorrect row echelon form: expected %b (rank 2), got %b (rank %v)", expectedEchelon.Rows, echelon.Rows, echelonRank)
+	}
+
+	expectedReduced := Matrix{Rows: []utils.BitVector{{0b1101}, {0b0110}, {0b0000}, {0b0000}}, ColumnCount: 4}
+	if !reduced.Equal(expectedReduced) || reducedRank != 2 {
+		t.Errorf("Incorrect reduced row echelon form: expected %b (rank 2), got %b (rank %v)", expectedReduced.Rows, reduced.Rows, reducedRank)
+	}
+
+	if rank != 2 {
+		t.Errorf("Incorrect rank: expected 2, got %v", rank)
+	}
+
+	// The original matrix should not change
+	if singularMatrix.Rows[0][0] != 0b0110 || singularMatrix.Rows[1][0] != 0b1011 {
+		t.Error("The original matrix was modified")
+	}
+}
+
+func TestInverse(t *testing.T) {
+	testCases := []struct {
+		name               string
+		matrix             Matrix
+		expectedInvertible bool
+		expectedInverse    Matrix
+	}{
+		{
+			name:               "Identity matrix",
+			matrix:             NewIdentityMatrix(70),
+			expectedInvertible: true,
+			expectedInverse:    NewIdentityMatrix(70),
+		},
+		{
+			name:               "Invertible matrix",
+			matrix:             invertibleMatrix,
+			expectedInvertible: true,
+			expectedInverse:    Matrix{Rows: []utils.BitVector{{0b110}, {0b111}, {0b101}}, ColumnCount: 3},
+		},
+		{
+			name:               "Singular matrix",
+			matrix:             singularMatrix,
+			expectedInvertible: false,
+		},
+		{
+			name:               "Non-square matrix",
+			matrix:             Matrix{Rows: []utils.BitVector{{0b01}, {0b10}, {0b11}}, ColumnCount: 2},
+			expectedInvertible: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Act
+			inverse, invertible := testCase.matrix.Inverse()
+
+			// Assert
+			if invertible != testCase.expectedInvertible {
+				t.Fatalf("Incorrect result for invertible: expected %v, got %v", testCase.expectedInvertible, invertible)
+			}
+
+			if !invertible {
+				return
+			}
+
+			if !inverse.Equal(testCase.expectedInverse) {
+				t.Errorf("Incorrect inverse: expected %b, got %b", testCase.expectedInverse.Rows, inverse.Rows)
+			}
+
+			if !testCase.matrix.Mul(inverse).Equal(NewIdentityMatrix(testCase.matrix.RowCount())) {
+				t.Error("The product of the matrix and its inverse is not the identity matrix")
+			}
+		})
+	}
+}
+
+func TestNullSpace(t *testing.T) {
+	testCases := []struct {
+		name          string
+		matrix        Matrix
+		expectedBasis []utils.BitVector
+	}{
+		{
+			name:          "Invertible matrix",
+			matrix:        invertibleMatrix,
+			expectedBasis: []utils.BitVector{},
+		},
+		{
+			name:   "Singular matrix",
+			matrix: singularMatrix,
+			expectedBasis: []utils.BitVector{
+				{0b0111},
+				{0b1001},
+			},
+		},
+		{
+			name:   "Empty matrix",
+			matrix: NewMatrix(2, 3),
+			expectedBasis: []utils.BitVector{
+				{0b001},
+				{0b010},
+				{0b100},
+			},
+		},
+		{
+			name:   "Wide matrix",
+			matrix: Matrix{Rows: []utils.BitVector{{0b101}}, ColumnCount: 3},
+			expectedBasis: []utils.BitVector{
+				{0b010},
+				{0b101},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Act
+			basis := testCase.matrix.NullSpace()
+
+			// Assert
+			if len(basis) != len(testCase.expectedBasis) {
+				t.Fatalf("Incorrect basis size: expected %v, got %v", len(testCase.expectedBasis), len(basis))
+			}
+
+			for i, vector := range basis {
+				if !vector.Equal(testCase.expectedBasis[i]) {
+					t.Errorf("Incorrect basis vector at %v: expected %b, got %b", i, testCase.expectedBasis[i], vector)
+				}
+
+				if !testCase.matrix.MulVector(vector).IsZero() {
+					t.Errorf("Basis vector %b is not in the null space", vector)
+				}
+			}
+		})
+	}
+}
+
+func TestMultiplication(t *testing.T) {
+	// Arrange
+	left := Matrix{Rows: []utils.BitVector{{0b011}, {0b101}}, ColumnCount: 3}
+	right := Matrix{Rows: []utils.BitVector{{0b01}, {0b11}, {0b10}}, ColumnCount: 2}
+
+	// Act
+	product := left.Mul(right)
+	vectorProduct := invertibleMatrix.MulVector(utils.BitVector{0b101})
+
+	// Assert
+	expectedProduct := Matrix{Rows: []utils.BitVector{{0b10}, {0b11}}, ColumnCount: 2}
+	if !product.Equal(expectedProduct) {
+		t.Errorf("Incorrect matrix product: expected %b, got %b", expectedProduct.Rows, product.Rows)
+	}
+
+	expectedVectorProduct := utils.BitVector{0b011}
+	if !vectorProduct.Equal(expectedVectorProduct) {
+		t.Errorf("Incorrect matrix-vector product: expected %b, got %b", expectedVectorProduct, vectorProduct)
+	}
+}
+
+func TestTranspose(t *testing.T) {
+	// Arrange
+	matrix := Matrix{Rows: []utils.BitVector{{0b011}, {0b101}}, ColumnCount: 3}
+
+	// Act
+	transposed := matrix.Transpose()
+
+	// Assert
+	expectedResult := Matrix{Rows: []utils.BitVector{{0b11}, {0b01}, {0b10}}, ColumnCount: 2}
+	if !transposed.Equal(expectedResult) {
+		t.Errorf("Incorrect result: expected %b, got %b", expectedResult.Rows, transposed.Rows)
+	}
+}
+
+func TestSolve(t *testing.T) {
+	testCases := []struct {
+		name             string
+		matrix           Matrix
+		constants        utils.BitVector
+		expectedSolvable bool
+		expectedSolution utils.BitVector
+	}{
+		{
+			name:             "Invertible matrix",
+			matrix:           invertibleMatrix,
+			constants:        utils.BitVector{0b101},
+			expectedSolvable: true,
+			expectedSolution: utils.BitVector{0b001},
+		},
+		{
+			name:             "Singular matrix with solution",
+			matrix:           singularMatrix,
+			constants:        utils.BitVector{0b0110},
+			expectedSolvable: true,
+			expectedSolution: utils.BitVector{0b0001},
+		},
+		{
+			name:             "Singular matrix without solution",
+			matrix:           singularMatrix,
+			constants:        utils.BitVector{0b1110},
+			expectedSolvable: false,
+		},
+		{
+			name:             "Homogeneous system",
+			matrix:           singularMatrix,
+			constants:        utils.BitVector{0b0000},
+			expectedSolvable: true,
+			expectedSolution: utils.BitVector{0b0000},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Act
+			solution, solvable := testCase.matrix.Solve(testCase.constants)
+
+			// Assert
+			if solvable != testCase.expectedSolvable {
+				t.Fatalf("Incorrect result for solvable: expected %v, got %v", testCase.expectedSolvable, solvable)
+			}
+
+			if !solvable {
+				return
+			}
+
+			if !solution.Equal(testCase.expectedSolution) {
+				t.Errorf("Incorrect solution: expected %b, got %b", testCase.expectedSolution, solution)
+			}
+
+			if !testCase.matrix.MulVector(solution).Equal(testCase.constants) {
+				t.Errorf("The solution %b does not satisfy the equations", solution)
+			}
+		})
+	}
+}
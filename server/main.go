@@ -51,10 +51,52 @@ func main() {
 func setupApiWithDependencies() api.Api {
 	gaussianEliminator := solver.NewGaussianEliminator()
 
-	optimizer := solver.NewBruteForceOptimizer()
+	optimizer := selectOptimizer(os.Getenv("OPTIMIZER"))
 	freeVariableFixer := solver.NewFreeVariableFixer(optimizer)
 
-	solver := solver.NewBoardSolver(gaussianEliminator, freeVariableFixer)
+	// The equations of a shape are only eliminated once, as the same shapes are solved over and over
+	boardSolver := solver.NewPrecomputedSolver(freeVariableFixer)
+	modularSolver := solver.NewModularSolver()
+	graphSolver := solver.NewGraphSolver(boardSolver)
+	enumerator := solver.NewSolutionEnumerator(gaussianEliminator)
+	corrector := solver.NewBoardCorrector(boardSolver)
 
-	return api.New(solver)
+	// The boards of the solution table are looked up instead of being solved, if there is one
+	apiSolver := boardSolver
+	if tablePath := os.Getenv("SOLUTION_TABLE"); tablePath != "" {
+		apiSolver = solver.NewTableSolver(loadSolutionTable(tablePath), boardSolver)
+	}
+
+	return api.New(apiSolver, modularSolver, graphSolver, enumerator, corrector)
+}
+
+// The brute-force optimizer is the fastest for the few free variables of the usual boards,
+// the branch-and-bound one is only faster for the boards that flipping single free variables nearly solves optimally
+func selectOptimizer(name string) solver.Optimizer {
+	switch name {
+	case "", "bruteForce":
+		return solver.NewBruteForceOptimizer()
+	case "branchAndBound":
+		return solver.NewBranchAndBoundOptimizer()
+	default:
+		log.Fatalf("Unknown optimizer %v", name)
+		return nil
+	}
+}
+
+func loadSolutionTable(path string) *solver.SolutionTable {
+	file, err := os.Open(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+
+	table, err := solver.ReadSolutionTable(file)
+	if err != nil {
+		log.Fatalf("Invalid solution table %v: %v", path, err)
+	}
+
+	shape := table.Shape()
+	log.Printf("Loaded the solution table of the %vx%v board from %v", shape.RowCount, shape.ColumnCount, path)
+	return table
 }
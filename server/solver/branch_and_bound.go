@@ -0,0 +1,345 @@
+package solver
+
+import (
+	"context"
+	"math/bits"
+	"server/utils"
+)
+
+type branchAndBoundOptimizer struct{}
+
+// NewBranchAndBoundOptimizer returns an optimizer that fixes the free variables one by one,
+// and skips the values that cannot lead to a lower cost than the best solution found so far, so it finds the same cost as the brute-force optimizer.
+// The bound only counts the terms that the fixed variables determine, or that depend on the same remaining ones, which is only enough to skip
+// most of the combinations when flipping single variables already finds a solution close to the optimal one (e.g. for periodic boards).
+// Otherwise, as with the boards made by random clicks, it still visits about 2^k of them, and is not faster than the brute-force optimizer.
+// It starts from the solution of flipping single variables, so it returns at least that one if the context is done early.
+func NewBranchAndBoundOptimizer() Optimizer {
+	return branchAndBoundOptimizer{}
+}
+
+// The state of the search. The cost of a solution is the cost of the "clicks" of the terms, which are the affected rows
+// and the free variables themselves, and a term is clicked if its constant differs from the parity of its variables that are set.
+type branchAndBound struct {
+	// The free variables in the order they are fixed
+	order []int
+	// The terms that each free variable appears in
+	columns []utils.BitVector
+	// The cost of each term, nil if every click costs 1
+	costs []int
+	// For each number of fixed variables, the terms that only depend on the fixed ones, and the groups of the rest,
+	// which depend on the same variables that are not fixed yet
+	determinedTerms []termSet
+	termGroups      [][]termSet
+
+	values        utils.BitVector
+	clickedTerms  utils.BitVector
+	optimalValues utils.BitVector
+	optimalCost   int
+
+	// The search stops once the context is done, which is checked every few visited nodes
+	ctx          context.Context
+	visitedNodes int
+	stopped      bool
+}
+
+// A set of terms, given by the words of a bit vector that have any of them, as the sets are usually sparse
+type termSet []termWord
+
+type termWord struct {
+	index int
+	bits  uint64
+}
+
+func (branchAndBoundOptimizer) determineOptimalValues(ctx context.Context, freeVariables *freeVariables) (utils.BitVector, bool) {
+	if len(freeVariables.indexes) == 0 || len(freeVariables.affectedRows) == 0 {
+		return utils.NewBitVector(len(freeVariables.indexes)), true
+	}
+
+	search := newBranchAndBound(ctx, freeVariables)
+	search.branch(0, search.lowerBound(0))
+	return search.optimalValues, !search.stopped
+}
+
+func newBranchAndBound(ctx context.Context, freeVariables *freeVariables) *branchAndBound {
+	variableCount := len(freeVariables.indexes)
+	rowCount := len(freeVariables.affectedRows)
+	termCount := rowCount + variableCount
+
+	// The free variables are terms after the affected rows, which are only clicked if they are set
+	columns := getAffectedColumns(freeVariables)
+	for i := range columns {
+		column := utils.NewBitVector(termCount)
+		copy(column, columns[i])
+		column.SetBit(rowCount + i)
+		columns[i] = column
+	}
+
+	clickedTerms := utils.NewBitVector(termCount)
+	copy(clickedTerms, getAffectedSolution(freeVariables))
+
+	var costs []int
+	variableCosts, rowCosts := getCosts(freeVariables)
+	if rowCosts != nil {
+		costs = append(rowCosts, variableCosts...)
+	}
+
+	search := &branchAndBound{
+		columns:       columns,
+		costs:         costs,
+		values:        utils.NewBitVector(variableCount),
+		clickedTerms:  clickedTerms.Clone(),
+		optimalValues: utils.NewBitVector(variableCount),
+		ctx:           ctx,
+	}
+
+	patterns := search.getPatterns(termCount)
+	search.order = orderVariables(patterns, variableCount)
+	search.groupTerms(patterns)
+
+	// The best solution found by flipping single variables is the first upper bound, so the search can skip more values from the start
+	search.optimalCost = search.improveLocally()
+	copy(search.optimalValues, search.values)
+	search.values = utils.NewBitVector(variableCount)
+	search.clickedTerms = clickedTerms
+
+	return search
+}
+
+// Returns the free variables that each term depends on
+func (s *branchAndBound) getPatterns(termCount int) []utils.BitVector {
+	patterns := make([]utils.BitVector, termCount)
+	for t := range patterns {
+		patterns[t] = utils.NewBitVector(len(s.columns))
+	}
+
+	for i, column := range s.columns {
+		for t := range patterns {
+			if column.TestBit(t) {
+				patterns[t].SetBit(i)
+			}
+		}
+	}
+
+	return patterns
+}
+
+// Orders the free variables so that fixing them determines as many terms as early as possible,
+// as the bound can only count the cost of the determined terms exactly
+func orderVariables(patterns []utils.BitVector, variableCount int) []int {
+	order := make([]int, 0, variableCount)
+	remaining := make([]utils.BitVector, len(patterns))
+	for t, pattern := range patterns {
+		remaining[t] = pattern.Clone()
+	}
+
+	fixed := utils.NewBitVector(variableCount)
+	for len(order) < variableCount {
+		// The terms that would be determined by each variable, and the ones it appears in to break the ties
+		determinedCounts := make([]int, variableCount)
+		appearanceCounts := make([]int, variableCount)
+		for _, pattern := range remaining {
+			switch pattern.OnesCount() {
+			case 0:
+			case 1:
+				determinedCounts[pattern.TrailingZeros()]++
+				fallthrough
+			default:
+				for i := 0; i < variableCount; i++ {
+					if pattern.TestBit(i) {
+						appearanceCounts[i]++
+					}
+				}
+			}
+		}
+
+		next := -1
+		for i := 0; i < variableCount; i++ {
+			if fixed.TestBit(i) {
+				continue
+			}
+
+			if next < 0 || determinedCounts[i] > determinedCounts[next] ||
+				determinedCounts[i] == determinedCounts[next] && appearanceCounts[i] > appearanceCounts[next] {
+				next = i
+			}
+		}
+
+		order = append(order, next)
+		fixed.SetBit(next)
+		for _, pattern := range remaining {
+			pattern.ClearBit(next)
+		}
+	}
+
+	return order
+}
+
+// Groups the terms by the free variables they depend on, after fixing each number of them.
+// The terms of a group are flipped together by the rest of the variables, so either the clicked or the other ones are clicked in the end,
+// and the cheaper of the two is a lower bound on their cost. A term alone in its group can always end up not clicked, so those are left out.
+func (s *branchAndBound) groupTerms(patterns []utils.BitVector) {
+	variableCount := len(s.order)
+	remaining := make([]utils.BitVector, len(patterns))
+	for t, pattern := range patterns {
+		remaining[t] = pattern.Clone()
+	}
+
+	s.determinedTerms = make([]termSet, variableCount+1)
+	s.termGroups = make([][]termSet, variableCount+1)
+	for fixedCount := 0; fixedCount <= variableCount; fixedCount++ {
+		if fixedCount > 0 {
+			for _, pattern := range remaining {
+				pattern.ClearBit(s.order[fixedCount-1])
+			}
+		}
+
+		determined := make([]int, 0)
+		groups := make(map[string][]int)
+		keys := make([]string, 0)
+		for t, pattern := range remaining {
+			if pattern.IsZero() {
+				determined = append(determined, t)
+				continue
+			}
+
+			key := patternKey(pattern)
+			if _, exists := groups[key]; !exists {
+				keys = append(keys, key)
+			}
+			groups[key] = append(groups[key], t)
+		}
+
+		s.determinedTerms[fixedCount] = newTermSet(determined)
+		for _, key := range keys {
+			if group := groups[key]; len(group) > 1 {
+				s.termGroups[fixedCount] = append(s.termGroups[fixedCount], newTermSet(group))
+			}
+		}
+	}
+}
+
+func patternKey(pattern utils.BitVector) string {
+	key := make([]byte, 0, 8*len(pattern))
+	for _, word := range pattern {
+		for i := 0; i < 64; i += 8 {
+			key = append(key, byte(word>>i))
+		}
+	}
+
+	return string(key)
+}
+
+func newTermSet(terms []int) termSet {
+	set := make(termSet, 0)
+	for _, t := range terms {
+		if len(set) == 0 || set[len(set)-1].index != t/64 {
+			set = append(set, termWord{index: t / 64})
+		}
+		set[len(set)-1].bits |= 1 << (t % 64)
+	}
+
+	return set
+}
+
+// Returns the cost of the clicked terms of the set and the cost of the rest of them
+func (s *branchAndBound) getCosts(set termSet) (clickedCost int, otherCost int) {
+	for _, word := range set {
+		clicked := word.bits & s.clickedTerms[word.index]
+		if s.costs == nil {
+			clickedCost += bits.OnesCount64(clicked)
+			otherCost += bits.OnesCount64(word.bits &^ clicked)
+			continue
+		}
+
+		for remaining := word.bits; remaining != 0; remaining &= remaining - 1 {
+			bit := bits.TrailingZeros64(remaining)
+			if clicked&(1<<bit) != 0 {
+				clickedCost += s.costs[word.index*64+bit]
+			} else {
+				otherCost += s.costs[word.index*64+bit]
+			}
+		}
+	}
+
+	return clickedCost, otherCost
+}
+
+func (s *branchAndBound) lowerBound(fixedCount int) int {
+	bound, _ := s.getCosts(s.determinedTerms[fixedCount])
+
+	for _, group := range s.termGroups[fixedCount] {
+		clickedCost, otherCost := s.getCosts(group)
+		if clickedCost < otherCost {
+			bound += clickedCost
+		} else {
+			bound += otherCost
+		}
+	}
+
+	return bound
+}
+
+// Flips the value of the free variable, along with the terms it appears in
+func (s *branchAndBound) flip(variable int) {
+	s.values.FlipBit(variable)
+	s.clickedTerms.Xor(s.columns[variable])
+}
+
+// Flips single free variables as long as it lowers the cost, and returns the cost of the solution it ends up with
+func (s *branchAndBound) improveLocally() int {
+	cost := s.lowerBound(len(s.order))
+	for improved := true; improved; {
+		improved = false
+		for variable := range s.columns {
+			s.flip(variable)
+			if flippedCost := s.lowerBound(len(s.order)); flippedCost < cost {
+				cost = flippedCost
+				improved = true
+			} else {
+				s.flip(variable)
+			}
+		}
+	}
+
+	return cost
+}
+
+// Tries both values of the next free variable, starting with the one with the lower bound,
+// unless the bound shows that no better solution can be found
+func (s *branchAndBound) branch(fixedCount int, bound int) {
+	if s.stopped || bound >= s.optimalCost {
+		return
+	}
+
+	if s.visitedNodes%contextCheckInterval == 0 && s.ctx.Err() != nil {
+		s.stopped = true
+		return
+	}
+	s.visitedNodes++
+
+	// Every term is determined once all the variables are fixed, so the bound is the cost of the solution
+	if fixedCount == len(s.order) {
+		s.optimalCost = bound
+		copy(s.optimalValues, s.values)
+		return
+	}
+
+	variable := s.order[fixedCount]
+	zeroBound := s.lowerBound(fixedCount + 1)
+	s.flip(variable)
+	oneBound := s.lowerBound(fixedCount + 1)
+
+	if oneBound < zeroBound {
+		s.branch(fixedCount+1, oneBound)
+		s.flip(variable)
+		s.branch(fixedCount+1, zeroBound)
+		return
+	}
+
+	s.flip(variable)
+	s.branch(fixedCount+1, zeroBound)
+	s.flip(variable)
+	s.branch(fixedCount+1, oneBound)
+	s.flip(variable)
+}
@@ -0,0 +1,205 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"server/utils"
+	"testing"
+)
+
+func TestBranchAndBoundOptimizer(t *testing.T) {
+	testCases := []struct {
+		name  string
+		shape Shape
+		costs bool
+	}{
+		{
+			name:  "Default board",
+			shape: DefaultShape,
+		},
+		{
+			name:  "4x4 board",
+			shape: Shape{RowCount: 4, ColumnCount: 4},
+		},
+		{
+			name:  "9x9 board",
+			shape: Shape{RowCount: 9, ColumnCount: 9},
+		},
+		{
+			name:  "19x19 board",
+			shape: Shape{RowCount: 19, ColumnCount: 19},
+		},
+		{
+			name:  "6x6 torus",
+			shape: Shape{RowCount: 6, ColumnCount: 6, Topology: ToroidalTopology},
+		},
+		{
+			name:  "12x12 torus",
+			shape: Shape{RowCount: 12, ColumnCount: 12, Topology: ToroidalTopology},
+		},
+		{
+			name:  "9x9 board with costs",
+			shape: Shape{RowCount: 9, ColumnCount: 9},
+			costs: true,
+		},
+		{
+			name:  "12x12 torus with costs",
+			shape: Shape{RowCount: 12, ColumnCount: 12, Topology: ToroidalTopology},
+			costs: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Arrange
+			var costs []int
+			if testCase.costs {
+				costs = make([]int, testCase.shape.CellCount())
+				for i := range costs {
+					costs[i] = 1 + i*7%5
+				}
+			}
+
+			// Different boards give different constants, with the same coefficients
+			for seed := 0; seed < 3; seed++ {
+				freeVariables := getEliminatedFreeVariables(testCase.shape, getPatternBoard(testCase.shape, seed), costs)
+				affectedSolution := getAffectedSolution(freeVariables)
+
+				// Act
+				expected, _ := NewBruteForceOptimizer().determineOptimalValues(context.Background(), freeVariables)
+				result, optimal := NewBranchAndBoundOptimizer().determineOptimalValues(context.Background(), freeVariables)
+
+				// Assert
+				if !optimal {
+					t.Errorf("The search for seed %v did not finish", seed)
+				}
+
+				// The optimal values can differ when several of them have the lowest cost, but their cost has to be the same
+				expectedCost := calculateResultForValues(freeVariables, affectedSolution, expected[0])
+				if cost := calculateResultForValues(freeVariables, affectedSolution, result[0]); cost != expectedCost {
+					t.Errorf("Incorrect cost for seed %v: expected %v, got %v (%b)", seed, expectedCost, cost, result)
+				}
+			}
+		})
+	}
+}
+
+func TestBranchAndBoundOptimizerWithoutAffectedRows(t *testing.T) {
+	// Arrange
+	freeVariables := freeVariables{indexes: []int{1, 3, 5}, affectedRows: make([]utils.BitVector, 0), constantRow: 25}
+
+	// Act
+	result, _ := NewBranchAndBoundOptimizer().determineOptimalValues(context.Background(), &freeVariables)
+
+	// Assert
+	if !result.IsZero() {
+		t.Errorf("Incorrect result: expected 0, got %b", result)
+	}
+}
+
+func TestSolveBoardWithBranchAndBoundOptimizer(t *testing.T) {
+	// Arrange
+	shape := Shape{RowCount: 4, ColumnCount: 4}
+	solver := NewBoardSolver(NewGaussianEliminator(), NewFreeVariableFixer(NewBranchAndBoundOptimizer()))
+
+	for board := uint64(0); board < 1<<shape.CellCount(); board += 97 {
+		// Act
+		solvability, solution, _ := solver.SolveBoard(context.Background(), shape, utils.BitVector{board}, Options{})
+
+		// Assert
+		if solvability != Solvable {
+			continue
+		}
+
+		if !reachesTarget(shape, utils.BitVector{board}, Options{}, solution) {
+			t.Fatalf("Incorrect solution for board %b: %b", board, solution)
+		}
+
+		if expected := findOptimalCost(shape, utils.BitVector{board}, Options{}); solution.OnesCount() != expected {
+			t.Fatalf("Incorrect number of clicks for board %b: expected %v, got %v", board, expected, solution.OnesCount())
+		}
+	}
+}
+
+// Compares the optimizers on boards with more free variables than the 5x5 board
+func BenchmarkBranchAndBoundOptimizer(b *testing.B) {
+	shapes := []struct {
+		name  string
+		shape Shape
+	}{
+		{
+			name:  "19x19 board, 16 free variables",
+			shape: Shape{RowCount: 19, ColumnCount: 19},
+		},
+		{
+			name:  "30x30 board, 20 free variables",
+			shape: Shape{RowCount: 30, ColumnCount: 30},
+		},
+		{
+			name:  "30x30 torus, 24 free variables",
+			shape: Shape{RowCount: 30, ColumnCount: 30, Topology: ToroidalTopology},
+		},
+	}
+
+	optimizers := []struct {
+		name      string
+		optimizer Optimizer
+	}{
+		{
+			name:      "Brute force",
+			optimizer: NewBruteForceOptimizer(),
+		},
+		{
+			name:      "Branch and bound",
+			optimizer: NewBranchAndBoundOptimizer(),
+		},
+	}
+
+	for _, shape := range shapes {
+		// The periodic board is solved by the local search already, so random boards show how the search does with nothing to skip
+		boards := []struct {
+			name  string
+			board utils.BitVector
+		}{
+			{
+				name:  "pattern board",
+				board: getPatternBoard(shape.shape, 0),
+			},
+		}
+		for seed := int64(1); seed <= 3; seed++ {
+			boards = append(boards, struct {
+				name  string
+				board utils.BitVector
+			}{
+				name:  fmt.Sprintf("random board %v", seed),
+				board: getRandomBoard(shape.shape, seed),
+			})
+		}
+
+		for _, board := range boards {
+			freeVariables := getEliminatedFreeVariables(shape.shape, board.board, nil)
+
+			for _, optimizer := range optimizers {
+				b.Run(shape.name+"/"+board.name+"/"+optimizer.name, func(b *testing.B) {
+					for i := 0; i < b.N; i++ {
+						optimizer.optimizer.determineOptimalValues(context.Background(), freeVariables)
+					}
+				})
+			}
+		}
+	}
+}
+
+// Returns a board that can be solved, made by clicking random cells
+func getRandomBoard(shape Shape, seed int64) utils.BitVector {
+	random := rand.New(rand.NewSource(seed))
+	clicks := utils.NewBitVector(shape.CellCount())
+	for i := 0; i < shape.CellCount(); i++ {
+		if random.Intn(2) == 1 {
+			clicks.SetBit(i)
+		}
+	}
+
+	return applyClicks(shape, utils.NewBitVector(shape.CellCount()), clicks)
+}
@@ -0,0 +1,97 @@
+package solver
+
+import (
+	"server/gf2"
+	"server/utils"
+)
+
+// Certificate proves that a board cannot be solved with parity checks: sets of cells, where every allowed click
+// toggles an even number of the cells, so the parity of the cells that differ from their target never changes
+type Certificate struct {
+	// The parity checks with an odd number of cells that differ from their target, after the mandatory clicks
+	FailingChecks []utils.BitVector
+}
+
+// Returns a single parity check that proves the board cannot be solved, or nil if there is none.
+// The checks are combinations of the equations, so it is only a quiet pattern whose dot product with the board is 1
+// if every cell is toggled by the clicks of the cells it toggles, without constraints and target mask.
+// With custom offsets or toggles it is just a set of cells, which the clicks toggle an even number of.
+func (c Certificate) Witness() utils.BitVector {
+	if len(c.FailingChecks) == 0 {
+		return nil
+	}
+
+	return c.FailingChecks[0]
+}
+
+func (e *solutionEnumerator) Certificate(shape Shape, board utils.BitVector, options Options) (Certificate, bool) {
+	checks, syndrome := getParityChecks(shape, board, options)
+
+	failingChecks := make([]utils.BitVector, 0)
+	for i, check := range checks {
+		if syndrome.TestBit(i) {
+			failingChecks = append(failingChecks, check)
+		}
+	}
+
+	return Certificate{FailingChecks: failingChecks}, len(failingChecks) > 0
+}
+
+// Returns the independent parity checks of the board, along with the syndrome, whose bits tell which of them fail.
+// The board can be solved if and only if none of them fail.
+func getParityChecks(shape Shape, board utils.BitVector, options Options) ([]utils.BitVector, utils.BitVector) {
+	augmentedMatrix := getAugmentedMatrix(shape, board, options)
+	variableCount := len(augmentedMatrix)
+	constantRow := variableCount
+	rows := extendWithUnitVectors(augmentedMatrix)
+
+	// The rows after the pivots have no coefficients left, so their combinations are the parity checks,
+	// and the ones with a non-zero constant fail
+	rank := gf2.TransformToRowEchelon(rows, variableCount)
+
+	checks := make([]utils.BitVector, 0)
+	syndrome := utils.NewBitVector(variableCount - rank)
+	for _, row := range rows[rank:] {
+		// The checks that only consist of the dropped equations are always satisfied
+		check := getRowCombination(shape, options, row, constantRow)
+		if check.IsZero() {
+			continue
+		}
+
+		if row.TestBit(constantRow) {
+			syndrome.SetBit(len(checks))
+		}
+		checks = append(checks, check)
+	}
+
+	return checks, syndrome
+}
+
+// Extends each equation with a unit vector after the constants, so the elimination records
+// the combination of the original equations that make up each row
+func extendWithUnitVectors(augmentedMatrix []utils.BitVector) []utils.BitVector {
+	variableCount := len(augmentedMatrix)
+	constantRow := variableCount
+
+	rows := make([]utils.BitVector, variableCount)
+	for i, row := range augmentedMatrix {
+		rows[i] = utils.NewBitVector(2*variableCount + 1)
+		copy(rows[i], row)
+		rows[i].SetBit(constantRow + 1 + i)
+	}
+
+	return rows
+}
+
+// Returns the cells whose original equations make up the extended row. The dropped equations of the cells
+// that can end up in any state are empty, so leaving their cells out changes nothing.
+func getRowCombination(shape Shape, options Options, row utils.BitVector, constantRow int) utils.BitVector {
+	combination := utils.NewBitVector(shape.CellCount())
+	for i, cell := range shape.Cells() {
+		if row.TestBit(constantRow+1+i) && options.hasTarget(cell) {
+			combination.SetBit(cell)
+		}
+	}
+
+	return combination
+}
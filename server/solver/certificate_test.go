@@ -0,0 +1,160 @@
+package solver
+
+import (
+	"server/utils"
+	"testing"
+)
+
+func TestCertificate(t *testing.T) {
+	testCases := []struct {
+		name             string
+		shape            Shape
+		board            utils.BitVector
+		options          Options
+		expectedSolvable bool
+	}{
+		{
+			name:             "Default board without solution",
+			shape:            DefaultShape,
+			board:            utils.BitVector{0b00000_00000_00000_00000_00001},
+			expectedSolvable: false,
+		},
+		{
+			name:             "Default board with solution",
+			shape:            DefaultShape,
+			board:            utils.BitVector{0b00101_00011_10001_01100_10011},
+			expectedSolvable: true,
+		},
+		{
+			name:             "4x4 board without solution",
+			shape:            Shape{RowCount: 4, ColumnCount: 4},
+			board:            utils.BitVector{0b0000_0000_0110_0000},
+			expectedSolvable: false,
+		},
+		{
+			name:             "Torus without solution",
+			shape:            Shape{RowCount: 5, ColumnCount: 5, Topology: ToroidalTopology},
+			board:            utils.BitVector{0b00000_00000_00100_00000_00000},
+			expectedSolvable: false,
+		},
+		{
+			name:             "Asymmetric neighbourhood without solution",
+			shape:            Shape{RowCount: 2, ColumnCount: 3, Topology: ToroidalTopology, Neighbourhood: []Offset{{0, 0}, {0, 1}}},
+			board:            utils.BitVector{0b000_001},
+			expectedSolvable: false,
+		},
+		{
+			name:             "Masked board without solution",
+			shape:            Shape{RowCount: 5, ColumnCount: 5, Mask: utils.BitVector{0b01110_11111_11111_11111_01110}},
+			board:            utils.BitVector{0b00000_00000_00000_00000_00010},
+			expectedSolvable: false,
+		},
+		{
+			name:             "Hexagonal board without solution",
+			shape:            Shape{RowCount: 3, ColumnCount: 5, Grid: HexagonalGrid},
+			board:            utils.BitVector{0b00000_00000_00001},
+			expectedSolvable: false,
+		},
+		{
+			name:             "Don't-care cells on a 4x4 board without solution",
+			shape:            Shape{RowCount: 4, ColumnCount: 4},
+			board:            utils.BitVector{0b0000_0000_0000_0001},
+			options:          Options{TargetMask: utils.BitVector{0b1111_1111_1111_0111}},
+			expectedSolvable: false,
+		},
+		{
+			name:             "Target without solution",
+			shape:            Shape{RowCount: 4, ColumnCount: 4},
+			board:            utils.BitVector{0b0000_0000_0000_0000},
+			options:          Options{Target: utils.BitVector{0b1000_0000_0000_0000}},
+			expectedSolvable: false,
+		},
+		{
+			name:             "Constraints without solution",
+			shape:            Shape{RowCount: 3, ColumnCount: 3},
+			board:            utils.BitVector{0b010_111_010},
+			options:          Options{ForbiddenClicks: utils.BitVector{0b000_010_000}, MandatoryClicks: utils.BitVector{0b000_000_001}},
+			expectedSolvable: false,
+		},
+	}
+
+	enumerator := NewSolutionEnumerator(NewGaussianEliminator())
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Act
+			certificate, unsolvable := enumerator.Certificate(testCase.shape, testCase.board, testCase.options)
+
+			// Assert
+			if unsolvable == testCase.expectedSolvable {
+				t.Fatalf("Incorrect result for unsolvable: expected %v, got %v", !testCase.expectedSolvable, unsolvable)
+			}
+
+			if !unsolvable {
+				return
+			}
+
+			for _, check := range certificate.FailingChecks {
+				if !provesUnsolvability(testCase.shape, testCase.board, testCase.options, check) {
+					t.Errorf("Incorrect parity check: %b does not prove that the board is unsolvable", check)
+				}
+			}
+		})
+	}
+}
+
+func TestWitnessIsQuietPattern(t *testing.T) {
+	// Arrange
+	board := utils.BitVector{0b00000_00000_00000_00000_00001}
+	enumerator := NewSolutionEnumerator(NewGaussianEliminator())
+
+	// Act
+	certificate, _ := enumerator.Certificate(DefaultShape, board, Options{})
+
+	// Assert
+	witness := certificate.Witness()
+	if !applyClicks(DefaultShape, utils.NewBitVector(DefaultShape.CellCount()), witness).IsZero() {
+		t.Errorf("Incorrect witness: %b is not a quiet pattern", witness)
+	}
+
+	if !witness.Dot(board) {
+		t.Errorf("Incorrect witness: the dot product of %b with the board is 0", witness)
+	}
+}
+
+func TestWitnessOfSolvableBoard(t *testing.T) {
+	// Arrange
+	enumerator := NewSolutionEnumerator(NewGaussianEliminator())
+
+	// Act
+	certificate, _ := enumerator.Certificate(DefaultShape, utils.NewBitVector(DefaultShape.CellCount()), Options{})
+
+	// Assert
+	if witness := certificate.Witness(); witness != nil {
+		t.Errorf("Incorrect witness: expected none, got %b", witness)
+	}
+}
+
+// Checks that every allowed click toggles an even number of the cells of the check,
+// while an odd number of them differ from their target after the mandatory clicks
+func provesUnsolvability(shape Shape, board utils.BitVector, options Options, check utils.BitVector) bool {
+	toggledCells := options.toggledCells(shape, board)
+	for _, cell := range shape.Cells() {
+		if options.isMandatory(cell) {
+			toggledCells.Xor(getFlipVector(shape, cell))
+			continue
+		}
+
+		if !options.isForbidden(cell) && getFlipVector(shape, cell).Dot(check) {
+			return false
+		}
+	}
+
+	for i := 0; i < shape.CellCount(); i++ {
+		if check.TestBit(i) && (!shape.HasCell(i) || !options.hasTarget(i)) {
+			return false
+		}
+	}
+
+	return toggledCells.Dot(check)
+}
@@ -1,6 +0,0 @@
-package solver
-
-const RowCount = uint8(5)
-const ColumnCount = uint8(5)
-const MatrixSize = RowCount * ColumnCount
-const constantRow = MatrixSize
@@ -0,0 +1,109 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"server/utils"
+)
+
+// The upper limit on the number of parity checks of a board that can be corrected, as every combination of them is searched
+const MaxParityCheckCount = 20
+
+// ErrTooManyParityChecks is returned for the unsolvable boards with more than the allowed number of parity checks
+var ErrTooManyParityChecks = fmt.Errorf("boards with more than %v parity checks cannot be corrected", MaxParityCheckCount)
+
+// BoardCorrector finds the nearest solvable board to an unsolvable one
+type BoardCorrector interface {
+	// Returns the fewest cells that have to be toggled to make the board solvable, along with the solution of the corrected board,
+	// which is the best one found before the context is done, and whether it is proven optimal
+	CorrectBoard(ctx context.Context, shape Shape, board utils.BitVector, options Options) (utils.BitVector, utils.BitVector, bool, error)
+}
+
+type boardCorrector struct {
+	boardSolver BoardSolver
+}
+
+func NewBoardCorrector(boardSolver BoardSolver) BoardCorrector {
+	return &boardCorrector{boardSolver: boardSolver}
+}
+
+func (c *boardCorrector) CorrectBoard(ctx context.Context, shape Shape, board utils.BitVector, options Options) (utils.BitVector, utils.BitVector, bool, error) {
+	checks, syndrome := getParityChecks(shape, board, options)
+
+	// A solvable board needs no corrections, however many parity checks it has
+	corrections := utils.NewBitVector(shape.CellCount())
+	if !syndrome.IsZero() {
+		if len(checks) > MaxParityCheckCount {
+			return nil, nil, false, ErrTooManyParityChecks
+		}
+
+		corrections = findMinimalCorrection(shape, checks, syndrome)
+	}
+
+	correctedBoard := utils.NewBitVector(shape.CellCount())
+	copy(correctedBoard, board)
+	correctedBoard.Xor(corrections)
+
+	solvability, solution, optimal := c.boardSolver.SolveBoard(ctx, shape, correctedBoard, options)
+	if solvability != Solvable {
+		return nil, nil, false, errors.New("the corrected board cannot be solved")
+	}
+
+	return corrections, solution, optimal, nil
+}
+
+// Finds the fewest cells whose toggling fixes the failing parity checks, which is the minimum-weight coset leader
+// of the syndrome. Toggling a cell flips the checks that contain it, so a breadth-first search over the syndromes
+// finds the shortest sequence of cells that flips exactly the failing checks.
+func findMinimalCorrection(shape Shape, checks []utils.BitVector, syndrome utils.BitVector) utils.BitVector {
+	corrections := utils.NewBitVector(shape.CellCount())
+	if syndrome.IsZero() {
+		return corrections
+	}
+
+	// The syndromes of the cells, with a bit for each check containing them
+	cells := make([]int, 0)
+	cellSyndromes := make([]uint32, 0)
+	for _, cell := range shape.Cells() {
+		cellSyndrome := uint32(0)
+		for i, check := range checks {
+			if check.TestBit(cell) {
+				cellSyndrome |= 1 << i
+			}
+		}
+
+		if cellSyndrome != 0 {
+			cells = append(cells, cell)
+			cellSyndromes = append(cellSyndromes, cellSyndrome)
+		}
+	}
+
+	// The last cell toggled to reach each syndrome, -1 for the ones not reached yet
+	target := uint32(syndrome[0])
+	previousCells := make([]int, 1<<len(checks))
+	for i := range previousCells {
+		previousCells[i] = -1
+	}
+
+	queue := []uint32{0}
+	for len(queue) > 0 && previousCells[target] < 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for i, cellSyndrome := range cellSyndromes {
+			next := current ^ cellSyndrome
+			if next != 0 && previousCells[next] < 0 {
+				previousCells[next] = i
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	// Every failing check contains a cell, so the cells generate all syndromes, and the target is always reached
+	for current := target; current != 0; current ^= cellSyndromes[previousCells[current]] {
+		corrections.FlipBit(cells[previousCells[current]])
+	}
+
+	return corrections
+}
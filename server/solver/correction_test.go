@@ -0,0 +1,169 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"server/utils"
+	"testing"
+)
+
+func TestCorrectBoard(t *testing.T) {
+	testCases := []struct {
+		name          string
+		shape         Shape
+		board         utils.BitVector
+		options       Options
+		expectedCount int
+	}{
+		{
+			name:          "Default board with a single mistake",
+			shape:         DefaultShape,
+			board:         utils.BitVector{0b00101_00011_10001_01100_10010},
+			expectedCount: 1,
+		},
+		{
+			name:          "Default board with solution",
+			shape:         DefaultShape,
+			board:         utils.BitVector{0b00101_00011_10001_01100_10011},
+			expectedCount: 0,
+		},
+		{
+			name:          "4x4 board with two mistakes",
+			shape:         Shape{RowCount: 4, ColumnCount: 4},
+			board:         utils.BitVector{0b0000_0000_0110_0000},
+			expectedCount: 2,
+		},
+		{
+			name:          "Torus without solution",
+			shape:         Shape{RowCount: 5, ColumnCount: 5, Topology: ToroidalTopology},
+			board:         utils.BitVector{0b00000_00000_00100_00000_00000},
+			expectedCount: 1,
+		},
+		{
+			name:          "Hexagonal board without solution",
+			shape:         Shape{RowCount: 3, ColumnCount: 5, Grid: HexagonalGrid},
+			board:         utils.BitVector{0b00000_00000_00001},
+			expectedCount: 1,
+		},
+		{
+			name:          "Don't-care cells on a 4x4 board without solution",
+			shape:         Shape{RowCount: 4, ColumnCount: 4},
+			board:         utils.BitVector{0b0000_0000_0000_0001},
+			options:       Options{TargetMask: utils.BitVector{0b1111_1111_1111_0111}},
+			expectedCount: 1,
+		},
+		{
+			name:          "Constraints without solution",
+			shape:         Shape{RowCount: 3, ColumnCount: 3},
+			board:         utils.BitVector{0b010_111_010},
+			options:       Options{ForbiddenClicks: utils.BitVector{0b000_010_000}, MandatoryClicks: utils.BitVector{0b000_000_001}},
+			expectedCount: 1,
+		},
+	}
+
+	boardSolver := NewBoardSolver(NewGaussianEliminator(), NewFreeVariableFixer(NewBruteForceOptimizer()))
+	corrector := NewBoardCorrector(boardSolver)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Act
+			corrections, solution, _, err := corrector.CorrectBoard(context.Background(), testCase.shape, testCase.board, testCase.options)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if corrections.OnesCount() != testCase.expectedCount {
+				t.Fatalf("Incorrect number of corrections: expected %v, got %v (%b)", testCase.expectedCount, corrections.OnesCount(), corrections)
+			}
+
+			for i := 0; i < testCase.shape.CellCount(); i++ {
+				if corrections.TestBit(i) && !testCase.shape.HasCell(i) {
+					t.Errorf("Incorrect corrections: %b toggles the missing cell %v", corrections, i)
+				}
+			}
+
+			correctedBoard := utils.NewBitVector(testCase.shape.CellCount())
+			copy(correctedBoard, testCase.board)
+			correctedBoard.Xor(corrections)
+
+			if !reachesTarget(testCase.shape, correctedBoard, testCase.options, solution) {
+				t.Errorf("Incorrect solution: %b does not solve the corrected board %b", solution, correctedBoard)
+			}
+
+			if !satisfiesConstraints(testCase.shape, testCase.options, solution) {
+				t.Errorf("Incorrect solution: %b does not satisfy the constraints", solution)
+			}
+
+			if hasSmallerCorrection(boardSolver, testCase.shape, testCase.board, testCase.options, testCase.expectedCount) {
+				t.Errorf("Incorrect test case: the board can be corrected with fewer than %v toggles", testCase.expectedCount)
+			}
+		})
+	}
+}
+
+func TestCorrectBoardWithTooManyChecks(t *testing.T) {
+	// Arrange
+	board := utils.BitVector{0b00000_00000_00000_00000_00001}
+	options := Options{ForbiddenClicks: utils.BitVector{0b11111_11111_11111_11111_11111}}
+	corrector := NewBoardCorrector(NewBoardSolver(NewGaussianEliminator(), NewFreeVariableFixer(NewZeroValueOptimizer())))
+
+	// Act
+	_, _, _, err := corrector.CorrectBoard(context.Background(), DefaultShape, board, options)
+
+	// Assert
+	if !errors.Is(err, ErrTooManyParityChecks) {
+		t.Errorf("Incorrect error for a board with too many parity checks: %v", err)
+	}
+}
+
+func TestCorrectSolvableBoardWithManyChecks(t *testing.T) {
+	// Arrange
+	// The board is solvable without any click, so the number of its parity checks does not matter
+	board := utils.BitVector{0b00000_00000_00000_00000_00000}
+	options := Options{ForbiddenClicks: utils.BitVector{0b11111_11111_11111_11111_11111}}
+	corrector := NewBoardCorrector(NewBoardSolver(NewGaussianEliminator(), NewFreeVariableFixer(NewZeroValueOptimizer())))
+
+	// Act
+	corrections, solution, optimal, err := corrector.CorrectBoard(context.Background(), DefaultShape, board, options)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !corrections.IsZero() || !solution.IsZero() || !optimal {
+		t.Errorf("Incorrect result: expected no corrections and no clicks, got %b and %b (optimal: %v)", corrections, solution, optimal)
+	}
+}
+
+// Tries every combination of fewer than count cells, to find out whether any of them makes the board solvable
+func hasSmallerCorrection(boardSolver BoardSolver, shape Shape, board utils.BitVector, options Options, count int) bool {
+	cells := shape.Cells()
+
+	var search func(board utils.BitVector, start int, remaining int) bool
+	search = func(board utils.BitVector, start int, remaining int) bool {
+		if solvability, _, _ := boardSolver.SolveBoard(context.Background(), shape, board, options); solvability == Solvable {
+			return true
+		}
+
+		if remaining == 0 {
+			return false
+		}
+
+		for i := start; i < len(cells); i++ {
+			correctedBoard := utils.NewBitVector(shape.CellCount())
+			copy(correctedBoard, board)
+			correctedBoard.FlipBit(cells[i])
+
+			if search(correctedBoard, i+1, remaining-1) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return count > 0 && search(board, 0, count-1)
+}
@@ -0,0 +1,150 @@
+package solver
+
+import (
+	"fmt"
+	"math/bits"
+	"server/utils"
+	"sort"
+)
+
+// The upper limit on the dimension of the solution space that can be sorted, as all solutions have to be listed for it
+const MaxSortedDimension = 16
+
+// SolutionSpace is the affine space of all solutions of a board: the particular solution
+// XORed with every combination of the basis vectors of the null space
+type SolutionSpace struct {
+	Particular utils.BitVector
+	Basis      []utils.BitVector
+}
+
+// SolutionEnumerator finds every solution of a board, not just the one with the lowest cost
+type SolutionEnumerator interface {
+	SolutionSpace(shape Shape, board utils.BitVector, options Options) (Solvability, SolutionSpace)
+	// Returns the basis of the quiet patterns of the shape, the sets of clicks that do not change any cell
+	QuietPatterns(shape Shape) []utils.BitVector
+	// Returns the proof of the board being unsolvable, and false if it can be solved
+	Certificate(shape Shape, board utils.BitVector, options Options) (Certificate, bool)
+}
+
+type solutionEnumerator struct {
+	gaussianEliminator GaussianEliminator
+}
+
+func NewSolutionEnumerator(gaussianEliminator GaussianEliminator) SolutionEnumerator {
+	return &solutionEnumerator{gaussianEliminator: gaussianEliminator}
+}
+
+func (e *solutionEnumerator) SolutionSpace(shape Shape, board utils.BitVector, options Options) (Solvability, SolutionSpace) {
+	augmentedMatrix := getAugmentedMatrix(shape, board, options)
+
+	solvable, finalRow := e.gaussianEliminator.gaussianEliminate(augmentedMatrix)
+	if !solvable {
+		return determineUnsolvability(e.gaussianEliminator, shape, board, options), SolutionSpace{}
+	}
+
+	return Solvable, getSolutionSpace(shape, augmentedMatrix, finalRow, options)
+}
+
+// The quiet patterns are the null space of the equations, so they are the basis of the solutions of an empty board,
+// and every solution of any board can be changed by them without changing the result
+func (e *solutionEnumerator) QuietPatterns(shape Shape) []utils.BitVector {
+	augmentedMatrix := getAugmentedMatrix(shape, utils.NewBitVector(shape.CellCount()), Options{})
+
+	// An empty board is always solvable, with not clicking anything
+	_, finalRow := e.gaussianEliminator.gaussianEliminate(augmentedMatrix)
+	return getSolutionSpace(shape, augmentedMatrix, finalRow, Options{}).Basis
+}
+
+// Reads the solution space from the augmented matrix in reduced row echelon form
+func getSolutionSpace(shape Shape, augmentedMatrix []utils.BitVector, finalRow int, options Options) SolutionSpace {
+	constantRow := len(augmentedMatrix)
+
+	// With every free variable set to 0, the pivot variables are equal to the constants
+	constraints := options.variableConstraints(shape)
+	particular := utils.NewBitVector(constantRow)
+	for i := 0; i < finalRow; i++ {
+		if augmentedMatrix[i].TestBit(constantRow) {
+			particular.SetBit(augmentedMatrix[i].TrailingZeros())
+		}
+	}
+
+	// Setting a free variable to 1 toggles the pivot variables of the rows it appears in,
+	// while the fixed variables have the same value in every solution
+	basis := make([]utils.BitVector, 0)
+	for _, index := range findFreeVariables(augmentedMatrix, finalRow).indexes {
+		if len(constraints.fixed) > 0 && constraints.fixed.TestBit(index) {
+			if constraints.values.TestBit(index) {
+				particular.SetBit(index)
+			}
+			continue
+		}
+
+		vector := utils.NewBitVector(constantRow)
+		vector.SetBit(index)
+		for i := 0; i < finalRow; i++ {
+			if augmentedMatrix[i].TestBit(index) {
+				vector.SetBit(augmentedMatrix[i].TrailingZeros())
+			}
+		}
+
+		basis = append(basis, expandSolution(shape, vector))
+	}
+
+	return SolutionSpace{Particular: expandSolution(shape, particular), Basis: basis}
+}
+
+// Returns the number of basis vectors, there are 2 to the power of it solutions
+func (s SolutionSpace) Dimension() int {
+	return len(s.Basis)
+}
+
+// Returns the solution at the given position of the enumeration order, which is the Gray code order,
+// so the index selects the basis vectors by the bits of its Gray code. The index has to be less than the number of solutions.
+func (s SolutionSpace) Solution(index uint64) utils.BitVector {
+	solution := s.Particular.Clone()
+
+	grayCode := index ^ (index >> 1)
+	for i := 0; grayCode != 0; i++ {
+		if grayCode&1 == 1 {
+			solution.Xor(s.Basis[i])
+		}
+		grayCode >>= 1
+	}
+
+	return solution
+}
+
+// Calls yield with every solution in the Gray code order, stopping early if it returns false.
+// Consecutive solutions differ in a single basis vector, so each of them only costs one XOR.
+func (s SolutionSpace) Enumerate(yield func(solution utils.BitVector) bool) {
+	solution := s.Particular.Clone()
+	if !yield(solution.Clone()) {
+		return
+	}
+
+	for index := uint64(1); index != 0 && (s.Dimension() >= 64 || index < 1<<s.Dimension()); index++ {
+		solution.Xor(s.Basis[bits.TrailingZeros64(index)])
+		if !yield(solution.Clone()) {
+			return
+		}
+	}
+}
+
+// Returns all solutions ordered by the number of clicks, the ones with the same number keep the enumeration order
+func (s SolutionSpace) SortedByClicks() ([]utils.BitVector, error) {
+	if s.Dimension() > MaxSortedDimension {
+		return nil, fmt.Errorf("only solution spaces with at most %v dimensions can be sorted", MaxSortedDimension)
+	}
+
+	solutions := make([]utils.BitVector, 0, 1<<s.Dimension())
+	s.Enumerate(func(solution utils.BitVector) bool {
+		solutions = append(solutions, solution)
+		return true
+	})
+
+	sort.SliceStable(solutions, func(i, j int) bool {
+		return solutions[i].OnesCount() < solutions[j].OnesCount()
+	})
+
+	return solutions, nil
+}
@@ -0,0 +1,265 @@
+package solver
+
+import (
+	"server/utils"
+	"testing"
+)
+
+func TestSolutionSpace(t *testing.T) {
+	testCases := []struct {
+		name              string
+		shape             Shape
+		board             utils.BitVector
+		options           Options
+		solvability       Solvability
+		expectedDimension int
+	}{
+		{
+			name:              "Default board",
+			shape:             DefaultShape,
+			board:             utils.BitVector{0b00101_00011_10001_01100_10011},
+			solvability:       Solvable,
+			expectedDimension: 2,
+		},
+		{
+			name:              "Default board without solution",
+			shape:             DefaultShape,
+			board:             utils.BitVector{0b00000_00000_00000_00000_00001},
+			solvability:       Unsolvable,
+			expectedDimension: 0,
+		},
+		{
+			name:              "3x3 board with a single solution",
+			shape:             Shape{RowCount: 3, ColumnCount: 3},
+			board:             utils.BitVector{0b010_111_010},
+			solvability:       Solvable,
+			expectedDimension: 0,
+		},
+		{
+			name:              "4x4 board",
+			shape:             Shape{RowCount: 4, ColumnCount: 4},
+			board:             utils.BitVector{0b0110_1001_1001_0110},
+			solvability:       Solvable,
+			expectedDimension: 4,
+		},
+		{
+			name:              "4x4 board with constraints",
+			shape:             Shape{RowCount: 4, ColumnCount: 4},
+			board:             utils.BitVector{0b0110_1001_1001_0110},
+			options:           Options{ForbiddenClicks: utils.BitVector{0b0000_0000_0000_0001}, MandatoryClicks: utils.BitVector{0b1000_0000_0000_0000}},
+			solvability:       Solvable,
+			expectedDimension: 2,
+		},
+		{
+			name:              "Constraints without solution",
+			shape:             Shape{RowCount: 3, ColumnCount: 3},
+			board:             utils.BitVector{0b010_111_010},
+			options:           Options{ForbiddenClicks: utils.BitVector{0b000_010_000}},
+			solvability:       UnsolvableUnderConstraints,
+			expectedDimension: 0,
+		},
+		{
+			name:              "Masked board with don't-care cells",
+			shape:             crossBoard,
+			board:             utils.BitVector{0b010_101_010},
+			options:           Options{TargetMask: utils.BitVector{0b010_010_010}},
+			solvability:       Solvable,
+			expectedDimension: 2,
+		},
+	}
+
+	enumerator := NewSolutionEnumerator(NewGaussianEliminator())
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Act
+			solvability, space := enumerator.SolutionSpace(testCase.shape, testCase.board, testCase.options)
+
+			// Assert
+			if solvability != testCase.solvability {
+				t.Fatalf("Incorrect result for solvability: expected %v, got %v", testCase.solvability, solvability)
+			}
+
+			if space.Dimension() != testCase.expectedDimension {
+				t.Fatalf("Incorrect result for dimension: expected %v, got %v", testCase.expectedDimension, space.Dimension())
+			}
+
+			if solvability != Solvable {
+				return
+			}
+
+			// Every enumerated solution has to be a different valid one, in the order of the indexes
+			seen := make(map[uint64]bool)
+			index := uint64(0)
+			space.Enumerate(func(solution utils.BitVector) bool {
+				if !reachesTarget(testCase.shape, testCase.board, testCase.options, solution) {
+					t.Errorf("Incorrect solution: %b does not reach the target", solution)
+				}
+
+				if !satisfiesConstraints(testCase.shape, testCase.options, solution) {
+					t.Errorf("Incorrect solution: %b does not satisfy the constraints", solution)
+				}
+
+				if seen[solution[0]] {
+					t.Errorf("Incorrect solution: %b was enumerated twice", solution)
+				}
+				seen[solution[0]] = true
+
+				if expected := space.Solution(index); !solution.Equal(expected) {
+					t.Errorf("Incorrect solution at index %v: expected %b, got %b", index, expected, solution)
+				}
+				index++
+
+				return true
+			})
+
+			if expectedCount := 1 << testCase.expectedDimension; len(seen) != expectedCount {
+				t.Errorf("Incorrect number of solutions: expected %v, got %v", expectedCount, len(seen))
+			}
+		})
+	}
+}
+
+func TestEnumerateStopsEarly(t *testing.T) {
+	// Arrange
+	shape := Shape{RowCount: 4, ColumnCount: 4}
+	_, space := NewSolutionEnumerator(NewGaussianEliminator()).SolutionSpace(shape, utils.BitVector{0b0110_1001_1001_0110}, Options{})
+
+	// Act
+	count := 0
+	space.Enumerate(func(solution utils.BitVector) bool {
+		count++
+		return count < 3
+	})
+
+	// Assert
+	if count != 3 {
+		t.Errorf("Incorrect number of enumerated solutions: expected 3, got %v", count)
+	}
+}
+
+func TestSortedByClicks(t *testing.T) {
+	// Arrange
+	shape := Shape{RowCount: 4, ColumnCount: 4}
+	board := utils.BitVector{0b0110_1001_1001_0110}
+	_, space := NewSolutionEnumerator(NewGaussianEliminator()).SolutionSpace(shape, board, Options{})
+
+	// Act
+	solutions, err := space.SortedByClicks()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(solutions) != 16 {
+		t.Fatalf("Incorrect number of solutions: expected 16, got %v", len(solutions))
+	}
+
+	if expected := findOptimalCost(shape, board, Options{}); solutions[0].OnesCount() != expected {
+		t.Errorf("Incorrect first solution: expected %v clicks, got %v (%b)", expected, solutions[0].OnesCount(), solutions[0])
+	}
+
+	for i := 1; i < len(solutions); i++ {
+		if solutions[i-1].OnesCount() > solutions[i].OnesCount() {
+			t.Errorf("Incorrect order: %b comes before %b", solutions[i-1], solutions[i])
+		}
+	}
+}
+
+func TestSortedByClicksOnLargeSpace(t *testing.T) {
+	// Arrange
+	space := SolutionSpace{Particular: utils.BitVector{0}, Basis: make([]utils.BitVector, MaxSortedDimension+1)}
+
+	// Act
+	_, err := space.SortedByClicks()
+
+	// Assert
+	if err == nil {
+		t.Error("Expected an error for a solution space that is too large to sort")
+	}
+}
+
+func TestQuietPatterns(t *testing.T) {
+	testCases := []struct {
+		name          string
+		shape         Shape
+		expectedCount int
+	}{
+		{
+			name:          "Default board",
+			shape:         DefaultShape,
+			expectedCount: 2,
+		},
+		{
+			name:          "4x4 board",
+			shape:         Shape{RowCount: 4, ColumnCount: 4},
+			expectedCount: 4,
+		},
+		{
+			name:          "3x3 board",
+			shape:         Shape{RowCount: 3, ColumnCount: 3},
+			expectedCount: 0,
+		},
+		{
+			name:          "5x5 torus",
+			shape:         Shape{RowCount: 5, ColumnCount: 5, Topology: ToroidalTopology},
+			expectedCount: 8,
+		},
+		{
+			name:          "3x3 torus",
+			shape:         Shape{RowCount: 3, ColumnCount: 3, Topology: ToroidalTopology},
+			expectedCount: 4,
+		},
+		{
+			name:          "Masked board with quiet patterns",
+			shape:         Shape{RowCount: 5, ColumnCount: 5, Mask: utils.BitVector{0b01110_11111_11111_11111_01110}},
+			expectedCount: 3,
+		},
+		{
+			name:          "Masked board",
+			shape:         crossBoard,
+			expectedCount: 0,
+		},
+		{
+			name:          "Hexagonal board",
+			shape:         Shape{RowCount: 3, ColumnCount: 5, Grid: HexagonalGrid},
+			expectedCount: 3,
+		},
+	}
+
+	enumerator := NewSolutionEnumerator(NewGaussianEliminator())
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Act
+			patterns := enumerator.QuietPatterns(testCase.shape)
+
+			// Assert
+			if len(patterns) != testCase.expectedCount {
+				t.Fatalf("Incorrect number of quiet patterns: expected %v, got %v", testCase.expectedCount, len(patterns))
+			}
+
+			for _, pattern := range patterns {
+				if !applyClicks(testCase.shape, utils.NewBitVector(testCase.shape.CellCount()), pattern).IsZero() {
+					t.Errorf("Incorrect quiet pattern: %b changes the board", pattern)
+				}
+
+				for i := 0; i < testCase.shape.CellCount(); i++ {
+					if pattern.TestBit(i) && !testCase.shape.HasCell(i) {
+						t.Errorf("Incorrect quiet pattern: %b clicks the missing cell %v", pattern, i)
+					}
+				}
+			}
+
+			// The patterns have to be independent, so none of their combinations can be empty
+			space := SolutionSpace{Particular: utils.NewBitVector(testCase.shape.CellCount()), Basis: patterns}
+			for index := uint64(1); index < 1<<len(patterns); index++ {
+				if space.Solution(index).IsZero() {
+					t.Errorf("Incorrect quiet patterns: they are not independent")
+					break
+				}
+			}
+		})
+	}
+}
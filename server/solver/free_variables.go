@@ -1,17 +1,31 @@
 package solver
 
 import (
-	"math/bits"
+	"context"
 	"server/utils"
 )
 
 type freeVariables struct {
-	indexes      []uint8
-	affectedRows []uint32
+	indexes      []int
+	affectedRows []utils.BitVector
+	constantRow  int
+	// The cost of clicking each variable, every click costs 1 if empty
+	costs []int
+}
+
+// The requirements on the variables of the equations
+type variableConstraints struct {
+	// The cost of clicking each variable, every click costs 1 if empty
+	costs []int
+	// The variables that have a fixed value, which have already been eliminated from the equations
+	fixed utils.BitVector
+	// The values of the fixed variables
+	values utils.BitVector
 }
 
 type FreeVariableFixer interface {
-	fixFreeVariables(augmentedMatrix *[MatrixSize]uint32, finalRow uint8)
+	// Returns whether the values of the free variables are proven optimal, which is not the case if the context was done before the optimizer finished
+	fixFreeVariables(ctx context.Context, augmentedMatrix []utils.BitVector, finalRow int, constraints variableConstraints) bool
 }
 
 type freeVariableFixer struct {
@@ -22,86 +36,123 @@ func NewFreeVariableFixer(optimizer Optimizer) FreeVariableFixer {
 	return &freeVariableFixer{optimizer: optimizer}
 }
 
-func (f *freeVariableFixer) fixFreeVariables(augmentedMatrix *[MatrixSize]uint32, finalRow uint8) {
+func (f *freeVariableFixer) fixFreeVariables(ctx context.Context, augmentedMatrix []utils.BitVector, finalRow int, constraints variableConstraints) bool {
 	// Find the free variables
 	freeVariables := findFreeVariables(augmentedMatrix, finalRow)
 	if len(freeVariables.indexes) == 0 {
-		return
+		return true
+	}
+	freeVariables.costs = constraints.costs
+
+	// The fixed variables do not appear in any equation, so they are free, but their values are already known
+	fixedIndexes := make([]int, 0)
+	if len(constraints.fixed) > 0 {
+		indexes := make([]int, 0, len(freeVariables.indexes))
+		for _, index := range freeVariables.indexes {
+			if constraints.fixed.TestBit(index) {
+				fixedIndexes = append(fixedIndexes, index)
+			} else {
+				indexes = append(indexes, index)
+			}
+		}
+		freeVariables.indexes = indexes
+	}
+
+	for i, index := range fixedIndexes {
+		value := constraints.values.TestBit(index)
+		augmentedMatrix[finalRow+len(freeVariables.indexes)+i] = getFreeVariableVector(index, freeVariables.constantRow, value)
+	}
+
+	if len(freeVariables.indexes) == 0 {
+		return true
 	}
 
-	// Find the optimal values for the free variables using brute force
-	optimalValues := f.optimizer.determineOptimalValues(&freeVariables)
+	// Find the optimal values for the free variables, or the best ones found before the context is done
+	optimalValues, optimal := f.optimizer.determineOptimalValues(ctx, &freeVariables)
 
 	// Set the free variables and do back-substitution according to the optimal values
-	for i := uint8(0); i < uint8(len(freeVariables.indexes)); i++ {
-		value := utils.TestBit(optimalValues, i)
-		vector := getFreeVariableVector(freeVariables.indexes[i], value)
+	for i, index := range freeVariables.indexes {
+		value := optimalValues.TestBit(i)
+		vector := getFreeVariableVector(index, freeVariables.constantRow, value)
 
-		for t := uint8(0); t < finalRow; t++ {
-			if utils.TestBit(augmentedMatrix[t], freeVariables.indexes[i]) {
-				augmentedMatrix[t] ^= vector
+		for t := 0; t < finalRow; t++ {
+			if augmentedMatrix[t].TestBit(index) {
+				augmentedMatrix[t].Xor(vector)
 			}
 		}
 
 		augmentedMatrix[finalRow+i] = vector
 	}
+
+	return optimal
 }
 
-func findFreeVariables(augmentedMatrix *[MatrixSize]uint32, finalRow uint8) freeVariables {
+func findFreeVariables(augmentedMatrix []utils.BitVector, finalRow int) freeVariables {
+	matrixSize := len(augmentedMatrix)
+	constantRow := matrixSize
+
 	// Check for the edge case when all rows are empty
 	if finalRow == 0 {
-		return getFreeVariablesOfEmptyMatrix()
+		return getFreeVariablesOfEmptyMatrix(matrixSize)
 	}
 
-	// Using signed index variables to eliminate the overflow at 0
-	signedI := int8(finalRow - 1)
-	signedJ := int8(MatrixSize - 1)
+	i := finalRow - 1
+	j := matrixSize - 1
 
 	// Check the matrix for free variables
-	indexes := make([]uint8, 0)
-	for signedI >= 0 && signedJ >= 0 && signedI != signedJ {
-		i := uint8(signedI)
-		j := uint8(signedJ)
-		pivotColumn := uint8(bits.TrailingZeros32(augmentedMatrix[i]))
+	indexes := make([]int, 0)
+	for i >= 0 && j >= 0 && i != j {
+		pivotColumn := augmentedMatrix[i].TrailingZeros()
 
 		// Store the free variables
 		for ; j > pivotColumn; j-- {
 			indexes = append(indexes, j)
 		}
 
-		signedI--
-		signedJ = int8(j) - 1
+		i--
+		j--
+	}
+
+	// The columns before the first pivot are free too
+	for ; i < 0 && j >= 0; j-- {
+		indexes = append(indexes, j)
 	}
 
 	if len(indexes) == 0 {
-		return freeVariables{indexes: indexes, affectedRows: make([]uint32, 0)}
+		return freeVariables{indexes: indexes, affectedRows: make([]utils.BitVector, 0), constantRow: constantRow}
 	}
 
 	// Find the rows in the matrix do not just have bits set in the pivot column
-	affectedRows := make([]uint32, 0, MatrixSize)
-	for i := uint8(0); i < finalRow; i++ {
-		if bits.OnesCount32(utils.ClearBit(augmentedMatrix[i], constantRow)) > 1 {
+	affectedRows := make([]utils.BitVector, 0, matrixSize)
+	for i := 0; i < finalRow; i++ {
+		coefficientCount := augmentedMatrix[i].OnesCount()
+		if augmentedMatrix[i].TestBit(constantRow) {
+			coefficientCount--
+		}
+
+		if coefficientCount > 1 {
 			affectedRows = append(affectedRows, augmentedMatrix[i])
 		}
 	}
 
-	return freeVariables{indexes, affectedRows}
+	return freeVariables{indexes: indexes, affectedRows: affectedRows, constantRow: constantRow}
 }
 
-func getFreeVariablesOfEmptyMatrix() freeVariables {
+func getFreeVariablesOfEmptyMatrix(matrixSize int) freeVariables {
 	// All rows of the matrix being empty means that all variables are free
-	indexes := make([]uint8, 0, MatrixSize)
-	for i := uint8(0); i < MatrixSize; i++ {
+	indexes := make([]int, 0, matrixSize)
+	for i := 0; i < matrixSize; i++ {
 		indexes = append(indexes, i)
 	}
 
-	return freeVariables{indexes: indexes, affectedRows: make([]uint32, 0)}
+	return freeVariables{indexes: indexes, affectedRows: make([]utils.BitVector, 0), constantRow: matrixSize}
 }
 
-func getFreeVariableVector(index uint8, value bool) (vector uint32) {
-	vector = utils.SetBit(vector, index)
+func getFreeVariableVector(index int, constantRow int, value bool) utils.BitVector {
+	vector := utils.NewBitVector(constantRow + 1)
+	vector.SetBit(index)
 	if value {
-		vector = utils.SetBit(vector, constantRow)
+		vector.SetBit(constantRow)
 	}
 
 	return vector
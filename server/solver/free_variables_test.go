@@ -1,7 +1,9 @@
 package solver
 
 import (
+	"context"
 	"reflect"
+	"server/utils"
 	"sort"
 	"testing"
 )
@@ -12,7 +14,14 @@ func (f *freeVariables) Len() int {
 }
 
 func (f *freeVariables) Less(i, j int) bool {
-	return f.affectedRows[i] < f.affectedRows[j]
+	a, b := f.affectedRows[i], f.affectedRows[j]
+	for t := len(a) - 1; t >= 0; t-- {
+		if a[t] != b[t] {
+			return a[t] < b[t]
+		}
+	}
+
+	return false
 }
 
 func (f *freeVariables) Swap(i, j int) {
@@ -24,19 +33,19 @@ type mockOptimizer struct {
 	t             *testing.T
 	allowCall     bool
 	freeVariables *freeVariables
-	optimalValues uint32
+	optimalValues utils.BitVector
 
 	wasCalled bool
 }
 
-func (m *mockOptimizer) determineOptimalValues(freeVariables *freeVariables) uint32 {
+func (m *mockOptimizer) determineOptimalValues(ctx context.Context, freeVariables *freeVariables) (utils.BitVector, bool) {
 	// Save that the mock was called
 	m.wasCalled = true
 
 	// Check whether it's OK to call the mock
 	if !m.allowCall {
 		m.t.Fatal("Mock optimizer should not be called")
-		return 0
+		return nil, false
 	}
 
 	// Sort the affected rows in freeVariable values, as the order does not matter
@@ -45,217 +54,258 @@ func (m *mockOptimizer) determineOptimalValues(freeVariables *freeVariables) uin
 
 	// Check whether we got the expected input
 	if reflect.DeepEqual(m.freeVariables, freeVariables) {
-		return m.optimalValues
+		return m.optimalValues, true
 	} else {
 		m.t.Fatal("Calling mock optimizer with unexpected input")
-		return 0
+		return nil, false
 	}
 }
 
 func TestFixFreeVariables(t *testing.T) {
 	testCases := []struct {
 		name                string
-		matrix              [MatrixSize]uint32
-		finalRow            uint8
+		matrix              []utils.BitVector
+		finalRow            int
+		constraints         variableConstraints
 		shouldCallOptimizer bool
 		freeVariables       *freeVariables
-		optimalValues       uint32
-		expectedResult      [MatrixSize]uint32
+		optimalValues       utils.BitVector
+		expectedResult      []utils.BitVector
 	}{
 		{
 			name: "Empty matrix",
-			matrix: [MatrixSize]uint32{
-				0b00_0000_0000_0000_0000_0000_0000,
-				0b00_0000_0000_0000_0000_0000_0000,
-				0b00_0000_0000_0000_0000_0000_0000,
-				0b00_0000_0000_0000_0000_0000_0000,
-				0b00_0000_0000_0000_0000_0000_0000,
-				0b00_0000_0000_0000_0000_0000_0000,
-				0b00_0000_0000_0000_0000_0000_0000,
-				0b00_0000_0000_0000_0000_0000_0000,
-				0b00_0000_0000_0000_0000_0000_0000,
-				0b00_0000_0000_0000_0000_0000_0000,
-				0b00_0000_0000_0000_0000_0000_0000,
-				0b00_0000_0000_0000_0000_0000_0000,
-				0b00_0000_0000_0000_0000_0000_0000,
-				0b00_0000_0000_0000_0000_0000_0000,
-				0b00_0000_0000_0000_0000_0000_0000,
-				0b00_0000_0000_0000_0000_0000_0000,
-				0b00_0000_0000_0000_0000_0000_0000,
-				0b00_0000_0000_0000_0000_0000_0000,
-				0b00_0000_0000_0000_0000_0000_0000,
-				0b00_0000_0000_0000_0000_0000_0000,
-				0b00_0000_0000_0000_0000_0000_0000,
-				0b00_0000_0000_0000_0000_0000_0000,
-				0b00_0000_0000_0000_0000_0000_0000,
-				0b00_0000_0000_0000_0000_0000_0000,
-				0b00_0000_0000_0000_0000_0000_0000,
+			matrix: []utils.BitVector{
+				{0b00_0000_0000_0000_0000_0000_0000},
+				{0b00_0000_0000_0000_0000_0000_0000},
+				{0b00_0000_0000_0000_0000_0000_0000},
+				{0b00_0000_0000_0000_0000_0000_0000},
+				{0b00_0000_0000_0000_0000_0000_0000},
+				{0b00_0000_0000_0000_0000_0000_0000},
+				{0b00_0000_0000_0000_0000_0000_0000},
+				{0b00_0000_0000_0000_0000_0000_0000},
+				{0b00_0000_0000_0000_0000_0000_0000},
+				{0b00_0000_0000_0000_0000_0000_0000},
+				{0b00_0000_0000_0000_0000_0000_0000},
+				{0b00_0000_0000_0000_0000_0000_0000},
+				{0b00_0000_0000_0000_0000_0000_0000},
+				{0b00_0000_0000_0000_0000_0000_0000},
+				{0b00_0000_0000_0000_0000_0000_0000},
+				{0b00_0000_0000_0000_0000_0000_0000},
+				{0b00_0000_0000_0000_0000_0000_0000},
+				{0b00_0000_0000_0000_0000_0000_0000},
+				{0b00_0000_0000_0000_0000_0000_0000},
+				{0b00_0000_0000_0000_0000_0000_0000},
+				{0b00_0000_0000_0000_0000_0000_0000},
+				{0b00_0000_0000_0000_0000_0000_0000},
+				{0b00_0000_0000_0000_0000_0000_0000},
+				{0b00_0000_0000_0000_0000_0000_0000},
+				{0b00_0000_0000_0000_0000_0000_0000},
 			},
 			finalRow:            0,
 			shouldCallOptimizer: true,
 			freeVariables: &freeVariables{
-				indexes:      []uint8{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24},
-				affectedRows: make([]uint32, 0),
+				indexes:      []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24},
+				affectedRows: make([]utils.BitVector, 0),
+				constantRow:  25,
 			},
-			optimalValues: 0b1_1000_0111_1101_1110_1110_1101,
-			expectedResult: [MatrixSize]uint32{
-				0b10_0000_0000_0000_0000_0000_0001,
-				0b00_0000_0000_0000_0000_0000_0010,
-				0b10_0000_0000_0000_0000_0000_0100,
-				0b10_0000_0000_0000_0000_0000_1000,
-				0b00_0000_0000_0000_0000_0001_0000,
-				0b10_0000_0000_0000_0000_0010_0000,
-				0b10_0000_0000_0000_0000_0100_0000,
-				0b10_0000_0000_0000_0000_1000_0000,
-				0b00_0000_0000_0000_0001_0000_0000,
-				0b10_0000_0000_0000_0010_0000_0000,
-				0b10_0000_0000_0000_0100_0000_0000,
-				0b10_0000_0000_0000_1000_0000_0000,
-				0b10_0000_0000_0001_0000_0000_0000,
-				0b00_0000_0000_0010_0000_0000_0000,
-				0b10_0000_0000_0100_0000_0000_0000,
-				0b10_0000_0000_1000_0000_0000_0000,
-				0b10_0000_0001_0000_0000_0000_0000,
-				0b10_0000_0010_0000_0000_0000_0000,
-				0b10_0000_0100_0000_0000_0000_0000,
-				0b00_0000_1000_0000_0000_0000_0000,
-				0b00_0001_0000_0000_0000_0000_0000,
-				0b00_0010_0000_0000_0000_0000_0000,
-				0b00_0100_0000_0000_0000_0000_0000,
-				0b10_1000_0000_0000_0000_0000_0000,
-				0b11_0000_0000_0000_0000_0000_0000,
+			optimalValues: utils.BitVector{0b1_1000_0111_1101_1110_1110_1101},
+			expectedResult: []utils.BitVector{
+				{0b10_0000_0000_0000_0000_0000_0001},
+				{0b00_0000_0000_0000_0000_0000_0010},
+				{0b10_0000_0000_0000_0000_0000_0100},
+				{0b10_0000_0000_0000_0000_0000_1000},
+				{0b00_0000_0000_0000_0000_0001_0000},
+				{0b10_0000_0000_0000_0000_0010_0000},
+				{0b10_0000_0000_0000_0000_0100_0000},
+				{0b10_0000_0000_0000_0000_1000_0000},
+				{0b00_0000_0000_0000_0001_0000_0000},
+				{0b10_0000_0000_0000_0010_0000_0000},
+				{0b10_0000_0000_0000_0100_0000_0000},
+				{0b10_0000_0000_0000_1000_0000_0000},
+				{0b10_0000_0000_0001_0000_0000_0000},
+				{0b00_0000_0000_0010_0000_0000_0000},
+				{0b10_0000_0000_0100_0000_0000_0000},
+				{0b10_0000_0000_1000_0000_0000_0000},
+				{0b10_0000_0001_0000_0000_0000_0000},
+				{0b10_0000_0010_0000_0000_0000_0000},
+				{0b10_0000_0100_0000_0000_0000_0000},
+				{0b00_0000_1000_0000_0000_0000_0000},
+				{0b00_0001_0000_0000_0000_0000_0000},
+				{0b00_0010_0000_0000_0000_0000_0000},
+				{0b00_0100_0000_0000_0000_0000_0000},
+				{0b10_1000_0000_0000_0000_0000_0000},
+				{0b11_0000_0000_0000_0000_0000_0000},
 			},
 		},
 		{
 			name: "No free variables",
-			matrix: [MatrixSize]uint32{
-				0b00_0000_0000_0000_0000_0000_0001,
-				0b00_0000_0000_0000_0000_0000_0010,
-				0b00_0000_0000_0000_0000_0000_0100,
-				0b10_0000_0000_0000_0000_0000_1000,
-				0b10_0000_0000_0000_0000_0001_0000,
-				0b10_0000_0000_0000_0000_0010_0000,
-				0b00_0000_0000_0000_0000_0100_0000,
-				0b00_0000_0000_0000_0000_1000_0000,
-				0b00_0000_0000_0000_0001_0000_0000,
-				0b10_0000_0000_0000_0010_0000_0000,
-				0b10_0000_0000_0000_0100_0000_0000,
-				0b10_0000_0000_0000_1000_0000_0000,
-				0b10_0000_0000_0001_0000_0000_0000,
-				0b00_0000_0000_0010_0000_0000_0000,
-				0b00_0000_0000_0100_0000_0000_0000,
-				0b00_0000_0000_1000_0000_0000_0000,
-				0b10_0000_0001_0000_0000_0000_0000,
-				0b10_0000_0010_0000_0000_0000_0000,
-				0b10_0000_0100_0000_0000_0000_0000,
-				0b00_0000_1000_0000_0000_0000_0000,
-				0b10_0001_0000_0000_0000_0000_0000,
-				0b10_0010_0000_0000_0000_0000_0000,
-				0b00_0100_0000_0000_0000_0000_0000,
-				0b00_1000_0000_0000_0000_0000_0000,
-				0b11_0000_0000_0000_0000_0000_0000,
+			matrix: []utils.BitVector{
+				{0b00_0000_0000_0000_0000_0000_0001},
+				{0b00_0000_0000_0000_0000_0000_0010},
+				{0b00_0000_0000_0000_0000_0000_0100},
+				{0b10_0000_0000_0000_0000_0000_1000},
+				{0b10_0000_0000_0000_0000_0001_0000},
+				{0b10_0000_0000_0000_0000_0010_0000},
+				{0b00_0000_0000_0000_0000_0100_0000},
+				{0b00_0000_0000_0000_0000_1000_0000},
+				{0b00_0000_0000_0000_0001_0000_0000},
+				{0b10_0000_0000_0000_0010_0000_0000},
+				{0b10_0000_0000_0000_0100_0000_0000},
+				{0b10_0000_0000_0000_1000_0000_0000},
+				{0b10_0000_0000_0001_0000_0000_0000},
+				{0b00_0000_0000_0010_0000_0000_0000},
+				{0b00_0000_0000_0100_0000_0000_0000},
+				{0b00_0000_0000_1000_0000_0000_0000},
+				{0b10_0000_0001_0000_0000_0000_0000},
+				{0b10_0000_0010_0000_0000_0000_0000},
+				{0b10_0000_0100_0000_0000_0000_0000},
+				{0b00_0000_1000_0000_0000_0000_0000},
+				{0b10_0001_0000_0000_0000_0000_0000},
+				{0b10_0010_0000_0000_0000_0000_0000},
+				{0b00_0100_0000_0000_0000_0000_0000},
+				{0b00_1000_0000_0000_0000_0000_0000},
+				{0b11_0000_0000_0000_0000_0000_0000},
 			},
-			finalRow:            MatrixSize,
+			finalRow:            25,
 			shouldCallOptimizer: false,
 			freeVariables:       nil,
-			optimalValues:       0b0,
-			expectedResult: [MatrixSize]uint32{
-				0b00_0000_0000_0000_0000_0000_0001,
-				0b00_0000_0000_0000_0000_0000_0010,
-				0b00_0000_0000_0000_0000_0000_0100,
-				0b10_0000_0000_0000_0000_0000_1000,
-				0b10_0000_0000_0000_0000_0001_0000,
-				0b10_0000_0000_0000_0000_0010_0000,
-				0b00_0000_0000_0000_0000_0100_0000,
-				0b00_0000_0000_0000_0000_1000_0000,
-				0b00_0000_0000_0000_0001_0000_0000,
-				0b10_0000_0000_0000_0010_0000_0000,
-				0b10_0000_0000_0000_0100_0000_0000,
-				0b10_0000_0000_0000_1000_0000_0000,
-				0b10_0000_0000_0001_0000_0000_0000,
-				0b00_0000_0000_0010_0000_0000_0000,
-				0b00_0000_0000_0100_0000_0000_0000,
-				0b00_0000_0000_1000_0000_0000_0000,
-				0b10_0000_0001_0000_0000_0000_0000,
-				0b10_0000_0010_0000_0000_0000_0000,
-				0b10_0000_0100_0000_0000_0000_0000,
-				0b00_0000_1000_0000_0000_0000_0000,
-				0b10_0001_0000_0000_0000_0000_0000,
-				0b10_0010_0000_0000_0000_0000_0000,
-				0b00_0100_0000_0000_0000_0000_0000,
-				0b00_1000_0000_0000_0000_0000_0000,
-				0b11_0000_0000_0000_0000_0000_0000,
+			optimalValues:       utils.BitVector{0b0},
+			expectedResult: []utils.BitVector{
+				{0b00_0000_0000_0000_0000_0000_0001},
+				{0b00_0000_0000_0000_0000_0000_0010},
+				{0b00_0000_0000_0000_0000_0000_0100},
+				{0b10_0000_0000_0000_0000_0000_1000},
+				{0b10_0000_0000_0000_0000_0001_0000},
+				{0b10_0000_0000_0000_0000_0010_0000},
+				{0b00_0000_0000_0000_0000_0100_0000},
+				{0b00_0000_0000_0000_0000_1000_0000},
+				{0b00_0000_0000_0000_0001_0000_0000},
+				{0b10_0000_0000_0000_0010_0000_0000},
+				{0b10_0000_0000_0000_0100_0000_0000},
+				{0b10_0000_0000_0000_1000_0000_0000},
+				{0b10_0000_0000_0001_0000_0000_0000},
+				{0b00_0000_0000_0010_0000_0000_0000},
+				{0b00_0000_0000_0100_0000_0000_0000},
+				{0b00_0000_0000_1000_0000_0000_0000},
+				{0b10_0000_0001_0000_0000_0000_0000},
+				{0b10_0000_0010_0000_0000_0000_0000},
+				{0b10_0000_0100_0000_0000_0000_0000},
+				{0b00_0000_1000_0000_0000_0000_0000},
+				{0b10_0001_0000_0000_0000_0000_0000},
+				{0b10_0010_0000_0000_0000_0000_0000},
+				{0b00_0100_0000_0000_0000_0000_0000},
+				{0b00_1000_0000_0000_0000_0000_0000},
+				{0b11_0000_0000_0000_0000_0000_0000},
 			},
 		},
 		{
 			name: "10 free variables",
-			matrix: [MatrixSize]uint32{
-				0b00_0000_0000_0000_1000_0001_0001,
-				0b00_0000_0000_0000_0000_0000_0100,
-				0b10_0000_0000_0000_0000_0011_1000,
-				0b00_0000_0001_0000_0001_0100_0000,
-				0b10_0000_0000_0000_0100_1000_0000,
-				0b10_0000_0000_0001_0000_0000_0000,
-				0b00_0000_0000_0010_0000_0000_0000,
-				0b00_0000_0000_0100_0000_0000_0000,
-				0b00_0000_1001_1000_0000_0000_0000,
-				0b00_0000_0010_0000_0000_0000_0000,
-				0b10_0000_0100_0000_0000_0000_0000,
-				0b00_0001_0000_0000_0000_0000_0000,
-				0b10_0010_0000_0000_0000_0000_0000,
-				0b11_0100_0000_0000_0000_0000_0000,
-				0b00_1000_0000_0000_0000_0000_0000,
-				0b00_0000_0000_0000_0000_0000_0000, // finalRow (first empty row)
-				0b00_0000_0000_0000_0000_0000_0000,
-				0b00_0000_0000_0000_0000_0000_0000,
-				0b00_0000_0000_0000_0000_0000_0000,
-				0b00_0000_0000_0000_0000_0000_0000,
-				0b00_0000_0000_0000_0000_0000_0000,
-				0b00_0000_0000_0000_0000_0000_0000,
-				0b00_0000_0000_0000_0000_0000_0000,
-				0b00_0000_0000_0000_0000_0000_0000,
-				0b00_0000_0000_0000_0000_0000_0000,
+			matrix: []utils.BitVector{
+				{0b00_0000_0000_0000_1000_0001_0001},
+				{0b00_0000_0000_0000_0000_0000_0100},
+				{0b10_0000_0000_0000_0000_0011_1000},
+				{0b00_0000_0001_0000_0001_0100_0000},
+				{0b10_0000_0000_0000_0100_1000_0000},
+				{0b10_0000_0000_0001_0000_0000_0000},
+				{0b00_0000_0000_0010_0000_0000_0000},
+				{0b00_0000_0000_0100_0000_0000_0000},
+				{0b00_0000_1001_1000_0000_0000_0000},
+				{0b00_0000_0010_0000_0000_0000_0000},
+				{0b10_0000_0100_0000_0000_0000_0000},
+				{0b00_0001_0000_0000_0000_0000_0000},
+				{0b10_0010_0000_0000_0000_0000_0000},
+				{0b11_0100_0000_0000_0000_0000_0000},
+				{0b00_1000_0000_0000_0000_0000_0000},
+				{0b00_0000_0000_0000_0000_0000_0000}, // finalRow (first empty row)
+				{0b00_0000_0000_0000_0000_0000_0000},
+				{0b00_0000_0000_0000_0000_0000_0000},
+				{0b00_0000_0000_0000_0000_0000_0000},
+				{0b00_0000_0000_0000_0000_0000_0000},
+				{0b00_0000_0000_0000_0000_0000_0000},
+				{0b00_0000_0000_0000_0000_0000_0000},
+				{0b00_0000_0000_0000_0000_0000_0000},
+				{0b00_0000_0000_0000_0000_0000_0000},
+				{0b00_0000_0000_0000_0000_0000_0000},
 			},
 			finalRow:            15,
 			shouldCallOptimizer: true,
 			freeVariables: &freeVariables{
-				indexes: []uint8{24, 19, 16, 11, 10, 9, 8, 5, 4, 1},
-				affectedRows: []uint32{
-					0b00_0000_0000_0000_1000_0001_0001,
-					0b10_0000_0000_0000_0000_0011_1000,
-					0b00_0000_0001_0000_0001_0100_0000,
-					0b10_0000_0000_0000_0100_1000_0000,
-					0b00_0000_1001_1000_0000_0000_0000,
-					0b11_0100_0000_0000_0000_0000_0000,
+				indexes: []int{24, 19, 16, 11, 10, 9, 8, 5, 4, 1},
+				affectedRows: []utils.BitVector{
+					{0b00_0000_0000_0000_1000_0001_0001},
+					{0b10_0000_0000_0000_0000_0011_1000},
+					{0b00_0000_0001_0000_0001_0100_0000},
+					{0b10_0000_0000_0000_0100_1000_0000},
+					{0b00_0000_1001_1000_0000_0000_0000},
+					{0b11_0100_0000_0000_0000_0000_0000},
 				},
+				constantRow: 25,
+			},
+			optimalValues: utils.BitVector{0b01_1111_0010},
+			expectedResult: []utils.BitVector{
+				{0b10_0000_0000_0000_0000_0000_0001},
+				{0b00_0000_0000_0000_0000_0000_0100},
+				{0b10_0000_0000_0000_0000_0000_1000},
+				{0b10_0000_0000_0000_0000_0100_0000},
+				{0b00_0000_0000_0000_0000_1000_0000},
+				{0b10_0000_0000_0001_0000_0000_0000},
+				{0b00_0000_0000_0010_0000_0000_0000},
+				{0b00_0000_0000_0100_0000_0000_0000},
+				{0b10_0000_0000_1000_0000_0000_0000},
+				{0b00_0000_0010_0000_0000_0000_0000},
+				{0b10_0000_0100_0000_0000_0000_0000},
+				{0b00_0001_0000_0000_0000_0000_0000},
+				{0b10_0010_0000_0000_0000_0000_0000},
+				{0b10_0100_0000_0000_0000_0000_0000},
+				{0b00_1000_0000_0000_0000_0000_0000},
+				{0b01_0000_0000_0000_0000_0000_0000}, // finalRow (first row with a fixed free variable)
+				{0b10_0000_1000_0000_0000_0000_0000},
+				{0b00_0000_0001_0000_0000_0000_0000},
+				{0b00_0000_0000_0000_1000_0000_0000},
+				{0b10_0000_0000_0000_0100_0000_0000},
+				{0b10_0000_0000_0000_0010_0000_0000},
+				{0b10_0000_0000_0000_0001_0000_0000},
+				{0b10_0000_0000_0000_0000_0010_0000},
+				{0b10_0000_0000_0000_0000_0001_0000},
+				{0b00_0000_0000_0000_0000_0000_0010},
+			},
+		},
+		{
+			name: "Fixed variable before the first pivot",
+			matrix: []utils.BitVector{
+				{0b1110},
+				{0b0000}, // finalRow
+				{0b0000},
+			},
+			finalRow:            1,
+			constraints:         variableConstraints{fixed: utils.BitVector{0b001}, values: utils.BitVector{0b001}},
+			shouldCallOptimizer: true,
+			freeVariables: &freeVariables{
+				indexes:      []int{2},
+				affectedRows: []utils.BitVector{{0b1110}},
+				constantRow:  3,
 			},
-			optimalValues: 0b01_1111_0010,
-			expectedResult: [MatrixSize]uint32{
-				0b10_0000_0000_0000_0000_0000_0001,
-				0b00_0000_0000_0000_0000_0000_0100,
-				0b10_0000_0000_0000_0000_0000_1000,
-				0b10_0000_0000_0000_0000_0100_0000,
-				0b00_0000_0000_0000_0000_1000_0000,
-				0b10_0000_0000_0001_0000_0000_0000,
-				0b00_0000_0000_0010_0000_0000_0000,
-				0b00_0000_0000_0100_0000_0000_0000,
-				0b10_0000_0000_1000_0000_0000_0000,
-				0b00_0000_0010_0000_0000_0000_0000,
-				0b10_0000_0100_0000_0000_0000_0000,
-				0b00_0001_0000_0000_0000_0000_0000,
-				0b10_0010_0000_0000_0000_0000_0000,
-				0b10_0100_0000_0000_0000_0000_0000,
-				0b00_1000_0000_0000_0000_0000_0000,
-				0b01_0000_0000_0000_0000_0000_0000, // finalRow (first row with a fixed free variable)
-				0b10_0000_1000_0000_0000_0000_0000,
-				0b00_0000_0001_0000_0000_0000_0000,
-				0b00_0000_0000_0000_1000_0000_0000,
-				0b10_0000_0000_0000_0100_0000_0000,
-				0b10_0000_0000_0000_0010_0000_0000,
-				0b10_0000_0000_0000_0001_0000_0000,
-				0b10_0000_0000_0000_0000_0010_0000,
-				0b10_0000_0000_0000_0000_0001_0000,
-				0b00_0000_0000_0000_0000_0000_0010,
+			optimalValues: utils.BitVector{0b0},
+			expectedResult: []utils.BitVector{
+				{0b1010},
+				{0b0100},
+				{0b1001},
+			},
+		},
+		{
+			name: "Only fixed variables",
+			matrix: []utils.BitVector{
+				{0b1010},
+				{0b0000}, // finalRow
+				{0b0000},
+			},
+			finalRow:            1,
+			constraints:         variableConstraints{fixed: utils.BitVector{0b101}, values: utils.BitVector{0b100}},
+			shouldCallOptimizer: false,
+			expectedResult: []utils.BitVector{
+				{0b1010},
+				{0b1100},
+				{0b0001},
 			},
 		},
 	}
@@ -272,7 +322,7 @@ func TestFixFreeVariables(t *testing.T) {
 			freeVariableFixer := NewFreeVariableFixer(&optimizer)
 
 			// Act
-			freeVariableFixer.fixFreeVariables(&testCase.matrix, testCase.finalRow)
+			freeVariableFixer.fixFreeVariables(context.Background(), testCase.matrix, testCase.finalRow, testCase.constraints)
 
 			// Assert
 			if !reflect.DeepEqual(testCase.expectedResult, testCase.matrix) {
@@ -1,12 +1,12 @@
 package solver
 
 import (
-	"math/bits"
+	"server/gf2"
 	"server/utils"
 )
 
 type GaussianEliminator interface {
-	gaussianEliminate(augmentedMatrix *[MatrixSize]uint32) (bool, uint8)
+	gaussianEliminate(augmentedMatrix []utils.BitVector) (bool, int)
 }
 
 type gaussianEliminator struct{}
@@ -15,9 +15,9 @@ func NewGaussianEliminator() GaussianEliminator {
 	return gaussianEliminator{}
 }
 
-func (gaussianEliminator) gaussianEliminate(augmentedMatrix *[MatrixSize]uint32) (bool, uint8) {
-	// Bring to row echelon form
-	finalRow := transformToRowEchelon(augmentedMatrix)
+func (gaussianEliminator) gaussianEliminate(augmentedMatrix []utils.BitVector) (bool, int) {
+	// Bring to row echelon form, the last column holds the constants so it cannot hold a pivot
+	finalRow := gf2.TransformToRowEchelon(augmentedMatrix, len(augmentedMatrix))
 
 	// Check for forbidden rows
 	if hasForbiddenRow(augmentedMatrix, finalRow) {
@@ -25,70 +25,16 @@ func (gaussianEliminator) gaussianEliminate(augmentedMatrix *[MatrixSize]uint32)
 	}
 
 	// Bring to reduced row echelon form
-	backSubstitution(augmentedMatrix, finalRow)
+	gf2.BackSubstitution(augmentedMatrix, finalRow)
 	return true, finalRow
 }
 
-func transformToRowEchelon(augmentedMatrix *[MatrixSize]uint32) uint8 {
-	i := uint8(0)
-	j := uint8(0)
-
-	for i < MatrixSize && j < MatrixSize {
-		if !utils.TestBit(augmentedMatrix[i], j) && !swapPivot(augmentedMatrix, i, j) {
-			j++
-			continue
-		}
-
-		for t := i + 1; t < MatrixSize; t++ {
-			if utils.TestBit(augmentedMatrix[t], j) {
-				augmentedMatrix[t] ^= augmentedMatrix[i]
-			}
-		}
-
-		i++
-		j++
-	}
-
-	return i
-}
-
-func swapPivot(augmentedMatrix *[MatrixSize]uint32, i, j uint8) bool {
-	for t := i + 1; t < MatrixSize; t++ {
-		if utils.TestBit(augmentedMatrix[t], j) {
-			augmentedMatrix[i], augmentedMatrix[t] = augmentedMatrix[t], augmentedMatrix[i]
+func hasForbiddenRow(augmentedMatrix []utils.BitVector, finalRow int) bool {
+	for t := finalRow; t < len(augmentedMatrix); t++ {
+		if !augmentedMatrix[t].IsZero() {
 			return true
 		}
 	}
 
 	return false
 }
-
-func hasForbiddenRow(augmentedMatrix *[MatrixSize]uint32, finalRow uint8) bool {
-	for t := finalRow; t < MatrixSize; t++ {
-		if augmentedMatrix[t] > 0 {
-			return true
-		}
-	}
-
-	return false
-}
-
-func backSubstitution(augmentedMatrix *[MatrixSize]uint32, finalRow uint8) {
-	// Using signed index variable to eliminate the overflow at 0
-	signedI := int8(finalRow - 1)
-
-	// Run back-substitution
-	for signedI >= 0 {
-		i := uint8(signedI)
-		pivotColumn := uint8(bits.TrailingZeros32(augmentedMatrix[i]))
-
-		// Back-substitution
-		for t := uint8(0); t < i; t++ {
-			if utils.TestBit(augmentedMatrix[t], pivotColumn) {
-				augmentedMatrix[t] ^= augmentedMatrix[i]
-			}
-		}
-
-		signedI--
-	}
-}
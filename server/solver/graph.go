@@ -0,0 +1,100 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"server/utils"
+)
+
+// Graph is an undirected graph of lights, where pressing a vertex toggles the vertex itself and all of its neighbours
+type Graph struct {
+	// The neighbours of each vertex, as the edges are undirected, it is enough to list each edge at one of its ends
+	Neighbours [][]int
+}
+
+type Edge struct {
+	From int
+	To   int
+}
+
+// GraphSolver solves the game on an arbitrary graph instead of a grid
+type GraphSolver interface {
+	// Returns whether the lights can be turned off, the vertices to press, and whether they are proven to be the fewest
+	SolveGraph(ctx context.Context, graph Graph, lights utils.BitVector) (bool, utils.BitVector, bool)
+}
+
+type graphSolver struct {
+	boardSolver BoardSolver
+}
+
+func NewGraphSolver(boardSolver BoardSolver) GraphSolver {
+	return &graphSolver{boardSolver}
+}
+
+// Creates the graph from the list of its edges
+func NewGraphFromEdges(vertexCount int, edges []Edge) (Graph, error) {
+	if vertexCount < 0 || vertexCount > MaxCellCount {
+		return Graph{}, fmt.Errorf("the graph can have at most %v vertices", MaxCellCount)
+	}
+
+	neighbours := make([][]int, vertexCount)
+	for _, edge := range edges {
+		if edge.From < 0 || edge.From >= vertexCount {
+			return Graph{}, fmt.Errorf("invalid vertex %v in edge %v", edge.From, edge)
+		}
+
+		neighbours[edge.From] = append(neighbours[edge.From], edge.To)
+	}
+
+	return Graph{neighbours}, nil
+}
+
+func (g Graph) VertexCount() int {
+	return len(g.Neighbours)
+}
+
+func (g Graph) Validate() error {
+	if g.VertexCount() == 0 {
+		return errors.New("the graph must have at least one vertex")
+	}
+
+	if g.VertexCount() > MaxCellCount {
+		return fmt.Errorf("the graph can have at most %v vertices", MaxCellCount)
+	}
+
+	for i, neighbours := range g.Neighbours {
+		for _, neighbour := range neighbours {
+			if neighbour < 0 || neighbour >= g.VertexCount() {
+				return fmt.Errorf("invalid neighbour %v of vertex %v", neighbour, i)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Returns the shape of a single row of cells, where the flip vectors toggle the neighbours in the graph
+func (g Graph) Shape() Shape {
+	vertexCount := g.VertexCount()
+
+	flipVectors := make([]utils.BitVector, vertexCount)
+	for i := range flipVectors {
+		flipVectors[i] = utils.NewBitVector(vertexCount)
+		flipVectors[i].SetBit(i)
+	}
+
+	for i, neighbours := range g.Neighbours {
+		for _, neighbour := range neighbours {
+			flipVectors[i].SetBit(neighbour)
+			flipVectors[neighbour].SetBit(i)
+		}
+	}
+
+	return Shape{RowCount: 1, ColumnCount: vertexCount, FlipVectors: flipVectors}
+}
+
+func (s *graphSolver) SolveGraph(ctx context.Context, graph Graph, lights utils.BitVector) (bool, utils.BitVector, bool) {
+	solvability, solution, optimal := s.boardSolver.SolveBoard(ctx, graph.Shape(), lights, Options{})
+	return solvability == Solvable, solution, optimal
+}
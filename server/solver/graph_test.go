@@ -0,0 +1,220 @@
+package solver
+
+import (
+	"context"
+	"reflect"
+	"server/utils"
+	"testing"
+)
+
+// A ring of five vertices
+var ringGraph = Graph{Neighbours: [][]int{{1}, {2}, {3}, {4}, {0}}}
+
+// A center vertex connected to four leaves
+var starGraph = Graph{Neighbours: [][]int{{1, 2, 3, 4}, {}, {}, {}, {}}}
+
+// The outer pentagon, the spokes and the inner pentagram of the Petersen graph
+var petersenGraph = Graph{Neighbours: [][]int{{1, 5}, {2, 6}, {3, 7}, {4, 8}, {0, 9}, {7}, {8}, {9}, {5}, {6}}}
+
+func TestNewGraphFromEdges(t *testing.T) {
+	testCases := []struct {
+		name          string
+		vertexCount   int
+		edges         []Edge
+		expectedGraph Graph
+		expectedValid bool
+	}{
+		{
+			name:          "Path",
+			vertexCount:   3,
+			edges:         []Edge{{0, 1}, {2, 1}},
+			expectedGraph: Graph{Neighbours: [][]int{{1}, nil, {1}}},
+			expectedValid: true,
+		},
+		{
+			name:          "Vertices without edges",
+			vertexCount:   2,
+			edges:         nil,
+			expectedGraph: Graph{Neighbours: [][]int{nil, nil}},
+			expectedValid: true,
+		},
+		{
+			name:          "Edge with invalid vertex",
+			vertexCount:   2,
+			edges:         []Edge{{0, 1}, {2, 1}},
+			expectedValid: false,
+		},
+		{
+			name:          "Too many vertices",
+			vertexCount:   MaxCellCount + 1,
+			expectedValid: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Act
+			graph, err := NewGraphFromEdges(testCase.vertexCount, testCase.edges)
+
+			// Assert
+			if (err == nil) != testCase.expectedValid {
+				t.Fatalf("Incorrect result for validity: expected %v, got error %v", testCase.expectedValid, err)
+			}
+
+			if err == nil && !reflect.DeepEqual(testCase.expectedGraph, graph) {
+				t.Errorf("Incorrect result for graph: expected %v, got %v", testCase.expectedGraph, graph)
+			}
+		})
+	}
+}
+
+func TestGraphValidation(t *testing.T) {
+	testCases := []struct {
+		name          string
+		graph         Graph
+		expectedValid bool
+	}{
+		{
+			name:          "Petersen graph",
+			graph:         petersenGraph,
+			expectedValid: true,
+		},
+		{
+			name:          "Single vertex",
+			graph:         Graph{Neighbours: [][]int{nil}},
+			expectedValid: true,
+		},
+		{
+			name:          "Empty graph",
+			graph:         Graph{},
+			expectedValid: false,
+		},
+		{
+			name:          "Too many vertices",
+			graph:         Graph{Neighbours: make([][]int, MaxCellCount+1)},
+			expectedValid: false,
+		},
+		{
+			name:          "Negative neighbour",
+			graph:         Graph{Neighbours: [][]int{{-1}, {}}},
+			expectedValid: false,
+		},
+		{
+			name:          "Neighbour outside the graph",
+			graph:         Graph{Neighbours: [][]int{{1}, {2}}},
+			expectedValid: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Act
+			err := testCase.graph.Validate()
+
+			// Assert
+			if (err == nil) != testCase.expectedValid {
+				t.Errorf("Incorrect result for validity: expected %v, got error %v", testCase.expectedValid, err)
+			}
+		})
+	}
+}
+
+func TestGraphShape(t *testing.T) {
+	// Arrange
+	graph := Graph{Neighbours: [][]int{{1}, nil, {1, 2}, {0}}}
+	expectedShape := Shape{
+		RowCount:    1,
+		ColumnCount: 4,
+		FlipVectors: []utils.BitVector{{0b1011}, {0b0111}, {0b0110}, {0b1001}},
+	}
+
+	// Act
+	shape := graph.Shape()
+
+	// Assert
+	if !reflect.DeepEqual(expectedShape, shape) {
+		t.Errorf("Incorrect result for shape: expected %v, got %v", expectedShape, shape)
+	}
+}
+
+func TestSolveGraph(t *testing.T) {
+	testCases := []struct {
+		name   string
+		graph  Graph
+		lights utils.BitVector
+	}{
+		{
+			name:   "Ring with a single light",
+			graph:  ringGraph,
+			lights: utils.BitVector{0b00001},
+		},
+		{
+			name:   "Ring with all lights",
+			graph:  ringGraph,
+			lights: utils.BitVector{0b11111},
+		},
+		{
+			name:   "Star with the center lit",
+			graph:  starGraph,
+			lights: utils.BitVector{0b00001},
+		},
+		{
+			name:   "Star with a leaf lit",
+			graph:  starGraph,
+			lights: utils.BitVector{0b00010},
+		},
+		{
+			name:   "Petersen graph with the outer pentagon lit",
+			graph:  petersenGraph,
+			lights: utils.BitVector{0b00000_11111},
+		},
+		{
+			name:   "Petersen graph with a spoke lit",
+			graph:  petersenGraph,
+			lights: utils.BitVector{0b00001_00001},
+		},
+	}
+
+	solver := NewGraphSolver(NewBoardSolver(NewGaussianEliminator(), NewFreeVariableFixer(NewBruteForceOptimizer())))
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Arrange
+			shape := testCase.graph.Shape()
+			expectedSolvable, expectedPresses := findOptimalPresses(shape, testCase.lights)
+
+			// Act
+			solvable, solution, _ := solver.SolveGraph(context.Background(), testCase.graph, testCase.lights)
+
+			// Assert
+			if solvable != expectedSolvable {
+				t.Fatalf("Incorrect result for solvable: expected %v, got %v", expectedSolvable, solvable)
+			}
+
+			if !solvable {
+				return
+			}
+
+			if !applyClicks(shape, testCase.lights, solution).IsZero() {
+				t.Errorf("Incorrect result for solution: %b does not turn off all lights", solution)
+			}
+
+			if presses := solution.OnesCount(); presses != expectedPresses {
+				t.Errorf("Incorrect result for solution: expected %v presses, got %v (%b)", expectedPresses, presses, solution)
+			}
+		})
+	}
+}
+
+// Tries every possible set of presses, and returns the lowest number of presses that turns off all lights
+func findOptimalPresses(shape Shape, lights utils.BitVector) (bool, int) {
+	optimalPresses := -1
+	for presses := uint64(0); presses < 1<<shape.CellCount(); presses++ {
+		clicks := utils.BitVector{presses}
+		if applyClicks(shape, lights, clicks).IsZero() && (optimalPresses < 0 || clicks.OnesCount() < optimalPresses) {
+			optimalPresses = clicks.OnesCount()
+		}
+	}
+
+	return optimalPresses >= 0, optimalPresses
+}
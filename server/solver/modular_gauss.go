@@ -0,0 +1,76 @@
+package solver
+
+import "context"
+
+// Brings the matrix to reduced row echelon form over the integers modulo a prime, choosing pivots
+// only from the first columnCount columns. The pivots are normalized to 1.
+// Returns the pivot column of each non-zero row, so the number of pivot columns is the rank,
+// or the error of the context if it is done before the elimination finishes.
+func transformToModularReducedRowEchelon(ctx context.Context, matrix [][]int, columnCount, modulus int) ([]int, error) {
+	pivotColumns := make([]int, 0, len(matrix))
+
+	i := 0
+	for j := 0; i < len(matrix) && j < columnCount; j++ {
+		// Every pivot goes through the whole matrix, so the context is checked before each of them
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if matrix[i][j] == 0 && !swapModularPivot(matrix, i, j) {
+			continue
+		}
+
+		// Normalize the pivot to 1
+		scaleRow(matrix[i], modularInverse(matrix[i][j], modulus), modulus)
+
+		// Eliminate the column from all other rows
+		for t := range matrix {
+			if t != i && matrix[t][j] != 0 {
+				subtractRow(matrix[t], matrix[i], matrix[t][j], modulus)
+			}
+		}
+
+		pivotColumns = append(pivotColumns, j)
+		i++
+	}
+
+	return pivotColumns, nil
+}
+
+func swapModularPivot(matrix [][]int, i, j int) bool {
+	for t := i + 1; t < len(matrix); t++ {
+		if matrix[t][j] != 0 {
+			matrix[i], matrix[t] = matrix[t], matrix[i]
+			return true
+		}
+	}
+
+	return false
+}
+
+func scaleRow(row []int, factor, modulus int) {
+	for j := range row {
+		row[j] = row[j] * factor % modulus
+	}
+}
+
+// Subtracts the factor times the other row from the row
+func subtractRow(row []int, other []int, factor, modulus int) {
+	for j := range row {
+		row[j] = modulo(row[j]-factor*other[j], modulus)
+	}
+}
+
+// Returns the multiplicative inverse of the value modulo a prime, using Fermat's little theorem
+func modularInverse(value, modulus int) int {
+	result := 1
+	base := value % modulus
+	for exponent := modulus - 2; exponent > 0; exponent /= 2 {
+		if exponent%2 == 1 {
+			result = result * base % modulus
+		}
+		base = base * base % modulus
+	}
+
+	return result
+}
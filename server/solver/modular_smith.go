@@ -0,0 +1,162 @@
+package solver
+
+import "context"
+
+// Brings the matrix to diagonal form over the integers modulo any (not necessarily prime) modulus,
+// choosing pivots only from the first columnCount columns, like the Smith normal form does. Only
+// invertible row and column operations are used, and every pivot is normalized to a divisor of the modulus.
+// The row operations are applied to the remaining columns too, while the column operations are recorded
+// in the returned transformation matrix V, so that the solution of the original system is x = V * y,
+// where y is the solution of the diagonal one.
+// Returns the transformation matrix and the number of pivots, which are in the top left of the matrix,
+// or the error of the context if it is done before the elimination finishes.
+func transformToModularDiagonalForm(ctx context.Context, matrix [][]int, columnCount, modulus int) ([][]int, int, error) {
+	transformation := make([][]int, columnCount)
+	for i := range transformation {
+		transformation[i] = make([]int, columnCount)
+		transformation[i][i] = 1
+	}
+
+	t := 0
+	for ; t < len(matrix) && t < columnCount; t++ {
+		// Every pivot goes through the whole matrix, so the context is checked before each of them
+		if err := ctx.Err(); err != nil {
+			return nil, 0, err
+		}
+
+		if !moveModularPivot(matrix, transformation, t, columnCount) {
+			break
+		}
+
+		normalizePivot(matrix[t], t, modulus)
+
+		// Clearing the row may bring back values to the column, so repeat until both are clear
+		for {
+			for i := t + 1; i < len(matrix); i++ {
+				if matrix[i][t] != 0 {
+					eliminateModularRow(matrix, i, t, modulus)
+				}
+			}
+
+			clear := true
+			for j := t + 1; j < columnCount; j++ {
+				if matrix[t][j] != 0 {
+					clear = eliminateModularColumn(matrix, transformation, j, t, modulus) && clear
+				}
+			}
+
+			if clear {
+				break
+			}
+		}
+	}
+
+	return transformation, t, nil
+}
+
+// Moves a non-zero element of the remaining submatrix to the pivot position, returns false if there are none
+func moveModularPivot(matrix, transformation [][]int, t, columnCount int) bool {
+	for i := t; i < len(matrix); i++ {
+		for j := t; j < columnCount; j++ {
+			if matrix[i][j] == 0 {
+				continue
+			}
+
+			matrix[t], matrix[i] = matrix[i], matrix[t]
+			swapColumns(matrix, t, j)
+			swapColumns(transformation, t, j)
+			return true
+		}
+	}
+
+	return false
+}
+
+// Multiplies the row with a unit, so that the pivot becomes the greatest common divisor of itself and the modulus
+func normalizePivot(row []int, t, modulus int) {
+	divisor := gcd(row[t], modulus)
+	for unit := 1; unit < modulus; unit++ {
+		if gcd(unit, modulus) == 1 && unit*row[t]%modulus == divisor {
+			scaleRow(row, unit, modulus)
+			return
+		}
+	}
+}
+
+// Clears the element of row i in the pivot column t
+func eliminateModularRow(matrix [][]int, i, t, modulus int) {
+	pivot, value := matrix[t][t], matrix[i][t]
+
+	// As the pivot divides the modulus, this can be done with a simple subtraction if it divides the value too
+	if value%pivot == 0 {
+		subtractRow(matrix[i], matrix[t], value/pivot, modulus)
+		return
+	}
+
+	// Otherwise combine the two rows with an invertible transformation that replaces the pivot with the greatest common divisor
+	divisor, a, b := extendedGCD(pivot, value)
+	for j := range matrix[t] {
+		matrix[t][j], matrix[i][j] =
+			modulo(a*matrix[t][j]+b*matrix[i][j], modulus),
+			modulo((pivot/divisor)*matrix[i][j]-(value/divisor)*matrix[t][j], modulus)
+	}
+
+	normalizePivot(matrix[t], t, modulus)
+}
+
+// Clears the element of column j in the pivot row t, returns false if this changed the pivot column
+func eliminateModularColumn(matrix, transformation [][]int, j, t, modulus int) bool {
+	pivot, value := matrix[t][t], matrix[t][j]
+
+	if value%pivot == 0 {
+		subtractColumn(matrix, j, t, value/pivot, modulus)
+		subtractColumn(transformation, j, t, value/pivot, modulus)
+		return true
+	}
+
+	divisor, a, b := extendedGCD(pivot, value)
+	combineColumns(matrix, t, j, a, b, pivot/divisor, value/divisor, modulus)
+	combineColumns(transformation, t, j, a, b, pivot/divisor, value/divisor, modulus)
+
+	normalizePivot(matrix[t], t, modulus)
+	return false
+}
+
+func swapColumns(matrix [][]int, j, k int) {
+	for _, row := range matrix {
+		row[j], row[k] = row[k], row[j]
+	}
+}
+
+// Subtracts the factor times column k from column j
+func subtractColumn(matrix [][]int, j, k, factor, modulus int) {
+	for _, row := range matrix {
+		row[j] = modulo(row[j]-factor*row[k], modulus)
+	}
+}
+
+// Replaces column t with a * column t + b * column j, and column j with p * column j - q * column t,
+// which is invertible if a * p + b * q = 1
+func combineColumns(matrix [][]int, t, j, a, b, p, q, modulus int) {
+	for _, row := range matrix {
+		row[t], row[j] = modulo(a*row[t]+b*row[j], modulus), modulo(p*row[j]-q*row[t], modulus)
+	}
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+
+	return a
+}
+
+// Returns the greatest common divisor of a and b, along with the coefficients x and y for which a * x + b * y equals it
+func extendedGCD(a, b int) (int, int, int) {
+	if b == 0 {
+		return a, 1, 0
+	}
+
+	divisor, x, y := extendedGCD(b, a%b)
+	return divisor, y, x - a/b*y
+}
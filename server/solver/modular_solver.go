@@ -0,0 +1,358 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+)
+
+// The upper limit on the number of states a cell can have in the multi-state puzzles
+const MaxStateCount = 64
+
+// The upper limit on the number of combinations of the free variables of a multi-state puzzle, as every one of them is tried
+const MaxModularCombinationCount = 1 << 20
+
+// ErrTooManyModularCombinations is returned for the boards whose free variables have more than the allowed number of combinations
+var ErrTooManyModularCombinations = fmt.Errorf("boards with more than %v combinations of the free variables cannot be solved", MaxModularCombinationCount)
+
+// ModularSolver solves boards where each cell cycles through a number of states, with a click
+// moving every toggled cell one state further (the states being the integers modulo the state count)
+type ModularSolver interface {
+	// Returns ErrTooManyModularCombinations if the board has too many free variables to try every combination of them,
+	// or the error of the context if it is done before the board is solved
+	SolveBoard(ctx context.Context, shape Shape, board []int, stateCount int) (bool, []int, error)
+}
+
+type modularSolver struct{}
+
+func NewModularSolver() ModularSolver {
+	return modularSolver{}
+}
+
+func ValidateStateCount(stateCount int) error {
+	if stateCount < 2 || stateCount > MaxStateCount {
+		return fmt.Errorf("the number of states has to be between 2 and %v", MaxStateCount)
+	}
+
+	return nil
+}
+
+func (modularSolver) SolveBoard(ctx context.Context, shape Shape, board []int, stateCount int) (bool, []int, error) {
+	// Division only works modulo a prime, other state counts need the diagonal form
+	if !isPrime(stateCount) {
+		return solveCompositeModularBoard(ctx, shape, board, stateCount)
+	}
+
+	// Create the initial augmented matrix
+	augmentedMatrix := getModularAugmentedMatrix(shape, board, stateCount)
+
+	// Run the gaussian elimination algorithm
+	matrixSize := len(augmentedMatrix)
+	pivotColumns, err := transformToModularReducedRowEchelon(ctx, augmentedMatrix, matrixSize, stateCount)
+	if err != nil {
+		return false, nil, err
+	}
+
+	// Check for forbidden rows
+	for _, row := range augmentedMatrix[len(pivotColumns):] {
+		if row[matrixSize] != 0 {
+			return false, nil, nil
+		}
+	}
+
+	freeVariableCount := matrixSize - len(pivotColumns)
+	if !hasFewModularCombinations(freeVariableCount, stateCount) {
+		return false, nil, ErrTooManyModularCombinations
+	}
+
+	// Fix the free variables to minimize the clicks needed in the solution
+	solution, err := determineOptimalModularSolution(ctx, augmentedMatrix, pivotColumns, stateCount)
+	if err != nil {
+		return false, nil, err
+	}
+
+	return true, expandModularSolution(shape, solution), nil
+}
+
+// Reports whether the free variables have at most the allowed number of combinations, without overflowing while counting them
+func hasFewModularCombinations(freeVariableCount int, stateCount int) bool {
+	combinationCount := 1
+	for i := 0; i < freeVariableCount; i++ {
+		combinationCount *= stateCount
+		if combinationCount > MaxModularCombinationCount {
+			return false
+		}
+	}
+
+	return true
+}
+
+func solveCompositeModularBoard(ctx context.Context, shape Shape, board []int, stateCount int) (bool, []int, error) {
+	// Create the initial augmented matrix
+	augmentedMatrix := getModularAugmentedMatrix(shape, board, stateCount)
+
+	// Bring it to diagonal form
+	matrixSize := len(augmentedMatrix)
+	constantRow := matrixSize
+	transformation, rank, err := transformToModularDiagonalForm(ctx, augmentedMatrix, matrixSize, stateCount)
+	if err != nil {
+		return false, nil, err
+	}
+
+	// Check for forbidden rows
+	for _, row := range augmentedMatrix[rank:] {
+		if row[constantRow] != 0 {
+			return false, nil, nil
+		}
+	}
+
+	// A pivot d divides the state count, so d * y = c has a solution only if d divides c too,
+	// in which case it has d different solutions, one in every (state count / d) states
+	values := make([]int, matrixSize)
+	variables := make([]diagonalVariable, 0, matrixSize)
+	for t := 0; t < rank; t++ {
+		pivot, constant := augmentedMatrix[t][t], augmentedMatrix[t][constantRow]
+		if constant%pivot != 0 {
+			return false, nil, nil
+		}
+
+		values[t] = constant / pivot
+		if pivot > 1 {
+			variables = append(variables, diagonalVariable{index: t, step: stateCount / pivot, count: pivot})
+		}
+	}
+
+	// The variables without a pivot can take any value
+	for t := rank; t < matrixSize; t++ {
+		variables = append(variables, diagonalVariable{index: t, step: 1, count: stateCount})
+	}
+
+	if !hasFewDiagonalCombinations(variables) {
+		return false, nil, ErrTooManyModularCombinations
+	}
+
+	// Fix the variables to minimize the clicks needed in the solution
+	solution, err := determineOptimalDiagonalSolution(ctx, transformation, values, variables, stateCount)
+	if err != nil {
+		return false, nil, err
+	}
+
+	return true, expandModularSolution(shape, solution), nil
+}
+
+// Reports whether the variables of the diagonal system have at most the allowed number of combinations
+func hasFewDiagonalCombinations(variables []diagonalVariable) bool {
+	combinationCount := 1
+	for _, variable := range variables {
+		combinationCount *= variable.count
+		if combinationCount > MaxModularCombinationCount {
+			return false
+		}
+	}
+
+	return true
+}
+
+// A variable of the diagonal system that has more than one possible value
+type diagonalVariable struct {
+	index int
+	step  int
+	count int
+}
+
+// Tries every possible value of the variables of the diagonal system, and returns the solution
+// of the original system with the fewest total clicks, or the error of the context if it is done before every value is tried
+func determineOptimalDiagonalSolution(ctx context.Context, transformation [][]int, values []int, variables []diagonalVariable, stateCount int) ([]int, error) {
+	matrixSize := len(transformation)
+
+	// Transform the particular solution and the steps of the variables back to the original system
+	particularSolution := make([]int, matrixSize)
+	steps := make([][]int, len(variables))
+	for i := range steps {
+		steps[i] = make([]int, matrixSize)
+	}
+
+	for i, row := range transformation {
+		for j, value := range values {
+			particularSolution[i] += row[j] * value
+		}
+		particularSolution[i] %= stateCount
+
+		for t, variable := range variables {
+			steps[t][i] = row[variable.index] * variable.step % stateCount
+		}
+	}
+
+	solution := make([]int, matrixSize)
+	optimalSolution := make([]int, matrixSize)
+	optimalResult := -1
+
+	choices := make([]int, len(variables))
+	for step := 1; ; step++ {
+		if step%contextCheckInterval == 0 && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		// Calculate the solution for the current values of the variables
+		result := 0
+		for i := range solution {
+			value := particularSolution[i]
+			for t, choice := range choices {
+				value += steps[t][i] * choice
+			}
+
+			solution[i] = value % stateCount
+			result += solution[i]
+		}
+
+		if optimalResult < 0 || optimalResult > result {
+			copy(optimalSolution, solution)
+			optimalResult = result
+		}
+
+		if !nextDiagonalValues(choices, variables) {
+			return optimalSolution, nil
+		}
+	}
+}
+
+func getModularAugmentedMatrix(shape Shape, board []int, stateCount int) [][]int {
+	cells := shape.Cells()
+	matrixSize := len(cells)
+	constantRow := matrixSize
+
+	// The clicks have to move every cell forward to the 0 state
+	matrix := make([][]int, matrixSize)
+	for i, cell := range cells {
+		matrix[i] = make([]int, matrixSize+1)
+		matrix[i][constantRow] = modulo(-board[cell], stateCount)
+	}
+
+	// The flip vector of a cell is the column of its variable
+	for j, cell := range cells {
+		flipVector := getFlipVector(shape, cell)
+		for i, otherCell := range cells {
+			if flipVector.TestBit(otherCell) {
+				matrix[i][j] = 1
+			}
+		}
+	}
+
+	return matrix
+}
+
+// Maps the clicks on the existing cells back to the indexes of the whole board
+func expandModularSolution(shape Shape, solution []int) []int {
+	if len(shape.Mask) == 0 {
+		return solution
+	}
+
+	expandedSolution := make([]int, shape.CellCount())
+	for i, cell := range shape.Cells() {
+		expandedSolution[cell] = solution[i]
+	}
+
+	return expandedSolution
+}
+
+// Tries every value of the free variables, and returns the solution with the fewest total clicks,
+// or the error of the context if it is done before every value is tried
+func determineOptimalModularSolution(ctx context.Context, augmentedMatrix [][]int, pivotColumns []int, stateCount int) ([]int, error) {
+	matrixSize := len(augmentedMatrix)
+	constantRow := matrixSize
+	freeVariables := findModularFreeVariables(matrixSize, pivotColumns)
+
+	solution := make([]int, matrixSize)
+	optimalSolution := make([]int, matrixSize)
+	optimalResult := -1
+
+	values := make([]int, len(freeVariables))
+	for step := 1; ; step++ {
+		if step%contextCheckInterval == 0 && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		// Calculate the solution for the current values of the free variables
+		result := 0
+		for i, index := range freeVariables {
+			solution[index] = values[i]
+			result += values[i]
+		}
+
+		for i, pivotColumn := range pivotColumns {
+			value := augmentedMatrix[i][constantRow]
+			for t, index := range freeVariables {
+				value -= augmentedMatrix[i][index] * values[t]
+			}
+
+			solution[pivotColumn] = modulo(value, stateCount)
+			result += solution[pivotColumn]
+		}
+
+		if optimalResult < 0 || optimalResult > result {
+			copy(optimalSolution, solution)
+			optimalResult = result
+		}
+
+		if !nextModularValues(values, stateCount) {
+			return optimalSolution, nil
+		}
+	}
+}
+
+func findModularFreeVariables(matrixSize int, pivotColumns []int) []int {
+	freeVariables := make([]int, 0, matrixSize-len(pivotColumns))
+
+	t := 0
+	for j := 0; j < matrixSize; j++ {
+		if t < len(pivotColumns) && pivotColumns[t] == j {
+			t++
+			continue
+		}
+
+		freeVariables = append(freeVariables, j)
+	}
+
+	return freeVariables
+}
+
+// Steps the values to the next combination like an odometer, returns false after the last one
+func nextModularValues(values []int, stateCount int) bool {
+	for i := range values {
+		values[i]++
+		if values[i] < stateCount {
+			return true
+		}
+
+		values[i] = 0
+	}
+
+	return false
+}
+
+// Steps the values to the next combination like an odometer, where each variable has its own number of choices
+func nextDiagonalValues(choices []int, variables []diagonalVariable) bool {
+	for i := range choices {
+		choices[i]++
+		if choices[i] < variables[i].count {
+			return true
+		}
+
+		choices[i] = 0
+	}
+
+	return false
+}
+
+func isPrime(value int) bool {
+	if value < 2 {
+		return false
+	}
+
+	for divisor := 2; divisor*divisor <= value; divisor++ {
+		if value%divisor == 0 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,480 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"server/utils"
+	"testing"
+	"time"
+)
+
+func TestModularReducedRowEchelon(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		matrix               [][]int
+		columnCount          int
+		modulus              int
+		expectedPivotColumns []int
+		expectedMatrix       [][]int
+	}{
+		{
+			name: "Invertible matrix modulo 3",
+			matrix: [][]int{
+				{2, 1, 1},
+				{1, 1, 0},
+			},
+			columnCount:          2,
+			modulus:              3,
+			expectedPivotColumns: []int{0, 1},
+			expectedMatrix: [][]int{
+				{1, 0, 1},
+				{0, 1, 2},
+			},
+		},
+		{
+			name: "Singular matrix modulo 5",
+			matrix: [][]int{
+				{1, 2, 3},
+				{2, 4, 2},
+				{0, 0, 0},
+			},
+			columnCount:          3,
+			modulus:              5,
+			expectedPivotColumns: []int{0, 2},
+			expectedMatrix: [][]int{
+				{1, 2, 0},
+				{0, 0, 1},
+				{0, 0, 0},
+			},
+		},
+		{
+			name: "Pivots restricted to the coefficient columns",
+			matrix: [][]int{
+				{0, 0, 1},
+				{0, 3, 2},
+			},
+			columnCount:          2,
+			modulus:              7,
+			expectedPivotColumns: []int{1},
+			expectedMatrix: [][]int{
+				{0, 1, 3},
+				{0, 0, 1},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Act
+			pivotColumns, _ := transformToModularReducedRowEchelon(context.Background(), testCase.matrix, testCase.columnCount, testCase.modulus)
+
+			// Assert
+			if !reflect.DeepEqual(testCase.expectedPivotColumns, pivotColumns) {
+				t.Errorf("Incorrect result for the pivot columns: expected %v, got %v", testCase.expectedPivotColumns, pivotColumns)
+			}
+
+			if !reflect.DeepEqual(testCase.expectedMatrix, testCase.matrix) {
+				t.Errorf("Incorrect result for the matrix: expected %v, got %v", testCase.expectedMatrix, testCase.matrix)
+			}
+		})
+	}
+}
+
+func TestModularDiagonalForm(t *testing.T) {
+	testCases := []struct {
+		name         string
+		matrix       [][]int
+		columnCount  int
+		modulus      int
+		expectedRank int
+	}{
+		{
+			name: "Invertible matrix modulo 4",
+			matrix: [][]int{
+				{1, 1, 3},
+				{1, 3, 2},
+			},
+			columnCount:  2,
+			modulus:      4,
+			expectedRank: 2,
+		},
+		{
+			name: "Singular matrix with zero divisors modulo 6",
+			matrix: [][]int{
+				{2, 3, 0, 1},
+				{4, 0, 3, 5},
+				{0, 3, 3, 2},
+			},
+			columnCount:  3,
+			modulus:      6,
+			expectedRank: 2,
+		},
+		{
+			name: "Singular matrix with column of zero divisors modulo 8",
+			matrix: [][]int{
+				{2, 4, 1},
+				{6, 4, 7},
+			},
+			columnCount:  2,
+			modulus:      8,
+			expectedRank: 1,
+		},
+		{
+			name: "Zero matrix modulo 9",
+			matrix: [][]int{
+				{0, 0, 1},
+				{0, 0, 2},
+			},
+			columnCount:  2,
+			modulus:      9,
+			expectedRank: 0,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Act
+			transformation, rank, _ := transformToModularDiagonalForm(context.Background(), testCase.matrix, testCase.columnCount, testCase.modulus)
+
+			// Assert
+			if rank != testCase.expectedRank {
+				t.Errorf("Incorrect result for the rank: expected %v, got %v", testCase.expectedRank, rank)
+			}
+
+			for i, row := range testCase.matrix {
+				for j, value := range row[:testCase.columnCount] {
+					if i == j && i < rank {
+						if testCase.modulus%value != 0 {
+							t.Errorf("Incorrect result for the matrix: pivot %v does not divide %v", value, testCase.modulus)
+						}
+					} else if value != 0 {
+						t.Errorf("Incorrect result for the matrix: %v is not in diagonal form", testCase.matrix)
+					}
+				}
+			}
+
+			if determinant := modulo(determinant(transformation), testCase.modulus); gcd(determinant, testCase.modulus) != 1 {
+				t.Errorf("Incorrect result for the transformation: %v is not invertible", transformation)
+			}
+		})
+	}
+}
+
+func TestModularInverse(t *testing.T) {
+	for _, modulus := range []int{2, 3, 5, 7, 61} {
+		for value := 1; value < modulus; value++ {
+			inverse := modularInverse(value, modulus)
+			if value*inverse%modulus != 1 {
+				t.Errorf("Incorrect inverse of %v modulo %v: got %v", value, modulus, inverse)
+			}
+		}
+	}
+}
+
+func TestValidateStateCount(t *testing.T) {
+	testCases := []struct {
+		stateCount    int
+		expectedValid bool
+	}{
+		{stateCount: 1, expectedValid: false},
+		{stateCount: 2, expectedValid: true},
+		{stateCount: 3, expectedValid: true},
+		{stateCount: 4, expectedValid: true},
+		{stateCount: 61, expectedValid: true},
+		{stateCount: 64, expectedValid: true},
+		{stateCount: 65, expectedValid: false},
+	}
+
+	for _, testCase := range testCases {
+		err := ValidateStateCount(testCase.stateCount)
+		if (err == nil) != testCase.expectedValid {
+			t.Errorf("Incorrect result for %v states: expected valid: %v, got error: %v", testCase.stateCount, testCase.expectedValid, err)
+		}
+	}
+}
+
+func TestModularSolveBoard(t *testing.T) {
+	testCases := []struct {
+		name       string
+		shape      Shape
+		board      []int
+		stateCount int
+	}{
+		{
+			name:       "Two states",
+			shape:      Shape{RowCount: 3, ColumnCount: 3},
+			board:      []int{1, 0, 1, 0, 1, 0, 1, 0, 1},
+			stateCount: 2,
+		},
+		{
+			name:       "Three states",
+			shape:      Shape{RowCount: 3, ColumnCount: 3},
+			board:      []int{2, 0, 1, 0, 1, 0, 1, 0, 2},
+			stateCount: 3,
+		},
+		{
+			name:       "Three states with free variables",
+			shape:      Shape{RowCount: 2, ColumnCount: 3},
+			board:      []int{1, 2, 0, 0, 2, 1},
+			stateCount: 3,
+		},
+		{
+			name:       "Three states without solution",
+			shape:      Shape{RowCount: 2, ColumnCount: 2},
+			board:      []int{1, 0, 0, 0},
+			stateCount: 3,
+		},
+		{
+			name:       "Five states with free variables",
+			shape:      Shape{RowCount: 2, ColumnCount: 3},
+			board:      []int{4, 0, 3, 1, 0, 2},
+			stateCount: 5,
+		},
+		{
+			name:       "Seven states with free variables",
+			shape:      Shape{RowCount: 2, ColumnCount: 3},
+			board:      []int{0, 6, 0, 0, 6, 0},
+			stateCount: 7,
+		},
+		{
+			name:       "Three states with Moore neighbourhood",
+			shape:      Shape{RowCount: 3, ColumnCount: 3, Neighbourhood: MooreNeighbourhood.Offsets(3, 3)},
+			board:      []int{0, 1, 2, 0, 1, 2, 0, 1, 2},
+			stateCount: 3,
+		},
+		{
+			name:       "Three states on a masked board",
+			shape:      crossBoard,
+			board:      []int{0, 1, 0, 2, 1, 0, 0, 2, 0},
+			stateCount: 3,
+		},
+		{
+			name:       "Three states on a hexagonal grid",
+			shape:      Shape{RowCount: 2, ColumnCount: 4, Grid: HexagonalGrid},
+			board:      []int{0, 1, 2, 0, 2, 0, 1, 1},
+			stateCount: 3,
+		},
+		{
+			name:       "Four states",
+			shape:      Shape{RowCount: 2, ColumnCount: 3},
+			board:      []int{1, 2, 3, 0, 1, 2},
+			stateCount: 4,
+		},
+		{
+			name:       "Four states with zero divisor pivots",
+			shape:      Shape{RowCount: 2, ColumnCount: 2},
+			board:      []int{2, 0, 0, 2},
+			stateCount: 4,
+		},
+		{
+			name:       "Four states without solution",
+			shape:      Shape{RowCount: 2, ColumnCount: 2},
+			board:      []int{1, 0, 0, 0},
+			stateCount: 4,
+		},
+		{
+			name:       "Six states",
+			shape:      Shape{RowCount: 2, ColumnCount: 3},
+			board:      []int{5, 0, 3, 1, 4, 2},
+			stateCount: 6,
+		},
+		{
+			name:       "Six states on a masked board",
+			shape:      Shape{RowCount: 2, ColumnCount: 3, Mask: utils.BitVector{0b111_001}},
+			board:      []int{5, 0, 0, 3, 2, 4},
+			stateCount: 6,
+		},
+		{
+			name:       "Six states on torus",
+			shape:      Shape{RowCount: 2, ColumnCount: 3, Topology: ToroidalTopology},
+			board:      []int{1, 0, 0, 0, 0, 5},
+			stateCount: 6,
+		},
+		{
+			name:       "Six states with Moore neighbourhood",
+			shape:      Shape{RowCount: 2, ColumnCount: 3, Neighbourhood: MooreNeighbourhood.Offsets(2, 3)},
+			board:      []int{3, 0, 3, 0, 2, 0},
+			stateCount: 6,
+		},
+		{
+			name:       "Eight states with Moore neighbourhood",
+			shape:      Shape{RowCount: 2, ColumnCount: 3, Neighbourhood: MooreNeighbourhood.Offsets(2, 3)},
+			board:      []int{4, 4, 0, 0, 4, 4},
+			stateCount: 8,
+		},
+	}
+
+	solver := NewModularSolver()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Arrange
+			expectedSolvable, expectedClicks := findOptimalModularClicks(testCase.shape, testCase.board, testCase.stateCount)
+
+			// Act
+			solvable, solution, err := solver.SolveBoard(context.Background(), testCase.shape, testCase.board, testCase.stateCount)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if solvable != expectedSolvable {
+				t.Fatalf("Incorrect result for solvable: expected %v, got %v", expectedSolvable, solvable)
+			}
+
+			if !solvable {
+				return
+			}
+
+			if !solvesModularBoard(testCase.shape, testCase.board, testCase.stateCount, solution) {
+				t.Errorf("Incorrect result for solution: %v does not bring all cells to the 0 state", solution)
+			}
+
+			for i, clicks := range solution {
+				if clicks != 0 && !testCase.shape.HasCell(i) {
+					t.Errorf("Incorrect result for solution: %v clicks the missing cell %v", solution, i)
+				}
+			}
+
+			if clicks := sum(solution); clicks != expectedClicks {
+				t.Errorf("Incorrect result for solution: expected %v clicks, got %v (%v)", expectedClicks, clicks, solution)
+			}
+		})
+	}
+}
+
+func TestModularSolveBoardOnLargerBoard(t *testing.T) {
+	// Arrange
+	shape := Shape{RowCount: 5, ColumnCount: 5}
+	board := []int{
+		0, 1, 2, 0, 1,
+		2, 0, 1, 2, 0,
+		1, 2, 0, 1, 2,
+		0, 1, 2, 0, 1,
+		2, 0, 1, 2, 0,
+	}
+
+	for _, stateCount := range []int{3, 4, 6} {
+		// Act
+		solvable, solution, _ := NewModularSolver().SolveBoard(context.Background(), shape, board, stateCount)
+
+		// Assert
+		if solvable && !solvesModularBoard(shape, board, stateCount, solution) {
+			t.Errorf("Incorrect result for solution with %v states: %v does not bring all cells to the 0 state", stateCount, solution)
+		}
+	}
+}
+
+func TestModularSolveBoardWithTooManyFreeVariables(t *testing.T) {
+	// Arrange
+	// The clicks only toggle cells outside of the board, so every variable is free, with 7^20 or 6^20 combinations
+	shape := Shape{RowCount: 1, ColumnCount: 20, Neighbourhood: []Offset{{Row: 5, Column: 0}}}
+	board := make([]int, shape.CellCount())
+
+	for _, stateCount := range []int{7, 6} {
+		// Act
+		_, _, err := NewModularSolver().SolveBoard(context.Background(), shape, board, stateCount)
+
+		// Assert
+		if !errors.Is(err, ErrTooManyModularCombinations) {
+			t.Errorf("Incorrect error with %v states: expected %v, got %v", stateCount, ErrTooManyModularCombinations, err)
+		}
+	}
+}
+
+func TestModularSolveBoardAfterDeadline(t *testing.T) {
+	// Arrange
+	// The elimination of a 32x32 board takes seconds, so it is stopped long before it finishes
+	shape := Shape{RowCount: 32, ColumnCount: 32}
+	board := make([]int, shape.CellCount())
+	for i := range board {
+		board[i] = i % 3
+	}
+
+	for _, stateCount := range []int{3, 6} {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+
+		// Act
+		start := time.Now()
+		_, _, err := NewModularSolver().SolveBoard(ctx, shape, board, stateCount)
+		elapsed := time.Since(start)
+		cancel()
+
+		// Assert
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Incorrect error with %v states: expected %v, got %v", stateCount, context.DeadlineExceeded, err)
+		}
+
+		if elapsed > time.Second {
+			t.Errorf("The solver did not stop at the deadline with %v states, it took %v", stateCount, elapsed)
+		}
+	}
+}
+
+// Tries every possible combination of clicks, and returns the lowest number of clicks that solves the board
+func findOptimalModularClicks(shape Shape, board []int, stateCount int) (bool, int) {
+	clicks := make([]int, shape.CellCount())
+	optimalClicks := -1
+
+	for {
+		if solvesModularBoard(shape, board, stateCount, clicks) && (optimalClicks < 0 || sum(clicks) < optimalClicks) {
+			optimalClicks = sum(clicks)
+		}
+
+		if !nextModularValues(clicks, stateCount) {
+			return optimalClicks >= 0, optimalClicks
+		}
+	}
+}
+
+func solvesModularBoard(shape Shape, board []int, stateCount int, clicks []int) bool {
+	result := make([]int, len(board))
+	copy(result, board)
+
+	for j, count := range clicks {
+		flipVector := getFlipVector(shape, j)
+		for i := range result {
+			if flipVector.TestBit(i) {
+				result[i] += count
+			}
+		}
+	}
+
+	for _, value := range result {
+		if value%stateCount != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func determinant(matrix [][]int) int {
+	if len(matrix) == 0 {
+		return 1
+	}
+
+	result := 0
+	sign := 1
+	for j, value := range matrix[0] {
+		minor := make([][]int, 0, len(matrix)-1)
+		for _, row := range matrix[1:] {
+			minor = append(minor, append(append([]int(nil), row[:j]...), row[j+1:]...))
+		}
+
+		result += sign * value * determinant(minor)
+		sign = -sign
+	}
+
+	return result
+}
+
+func sum(values []int) (result int) {
+	for _, value := range values {
+		result += value
+	}
+
+	return
+}
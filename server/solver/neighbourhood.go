@@ -0,0 +1,65 @@
+package solver
+
+// Offset is the position of a toggled cell relative to the clicked cell
+type Offset struct {
+	Row    int
+	Column int
+}
+
+type NeighbourhoodPreset int
+
+// All the presets include the clicked cell itself as well
+const (
+	// The clicked cell and its north, south, west and east neighbours (the plus shape)
+	VonNeumannNeighbourhood NeighbourhoodPreset = iota
+	// The clicked cell and all eight cells around it
+	MooreNeighbourhood
+	// The clicked cell and its four diagonal neighbours (the X shape)
+	XNeighbourhood
+	// The clicked cell and the eight cells a knight can move to in chess
+	KnightNeighbourhood
+	// The whole row and column of the clicked cell
+	CrossNeighbourhood
+)
+
+var vonNeumannOffsets = []Offset{{0, 0}, {-1, 0}, {1, 0}, {0, -1}, {0, 1}}
+var mooreOffsets = []Offset{{0, 0}, {-1, -1}, {-1, 0}, {-1, 1}, {0, -1}, {0, 1}, {1, -1}, {1, 0}, {1, 1}}
+var xOffsets = []Offset{{0, 0}, {-1, -1}, {-1, 1}, {1, -1}, {1, 1}}
+var knightOffsets = []Offset{{0, 0}, {-2, -1}, {-2, 1}, {-1, -2}, {-1, 2}, {1, -2}, {1, 2}, {2, -1}, {2, 1}}
+
+// The clicked cell and its six neighbours on a hexagonal grid, which depend on whether the row is shifted
+var evenRowHexagonalOffsets = []Offset{{0, 0}, {-1, -1}, {-1, 0}, {0, -1}, {0, 1}, {1, -1}, {1, 0}}
+var oddRowHexagonalOffsets = []Offset{{0, 0}, {-1, 0}, {-1, 1}, {0, -1}, {0, 1}, {1, 0}, {1, 1}}
+
+// Offsets returns the relative offsets of the preset on a board with the given dimensions
+func (p NeighbourhoodPreset) Offsets(rowCount, columnCount int) []Offset {
+	switch p {
+	case VonNeumannNeighbourhood:
+		return vonNeumannOffsets
+	case MooreNeighbourhood:
+		return mooreOffsets
+	case XNeighbourhood:
+		return xOffsets
+	case KnightNeighbourhood:
+		return knightOffsets
+	case CrossNeighbourhood:
+		return getCrossOffsets(rowCount, columnCount)
+	default:
+		return nil
+	}
+}
+
+func getCrossOffsets(rowCount, columnCount int) []Offset {
+	offsets := []Offset{{0, 0}}
+
+	// Every other cell of the row and column is reachable whichever cell is clicked
+	for i := 1; i < rowCount; i++ {
+		offsets = append(offsets, Offset{-i, 0}, Offset{i, 0})
+	}
+
+	for j := 1; j < columnCount; j++ {
+		offsets = append(offsets, Offset{0, -j}, Offset{0, j})
+	}
+
+	return offsets
+}
@@ -1,12 +1,18 @@
 package solver
 
 import (
+	"context"
 	"math/bits"
 	"server/utils"
 )
 
+// The number of steps an optimizer takes between checking whether its context is done
+const contextCheckInterval = 1 << 12
+
+// Optimizer chooses the values of the free variables that minimize the cost of the solution. The optimizers are anytime algorithms,
+// once the context is done they return the best values found so far, and whether the search finished, proving them optimal.
 type Optimizer interface {
-	determineOptimalValues(freeVariables *freeVariables) uint32
+	determineOptimalValues(ctx context.Context, freeVariables *freeVariables) (utils.BitVector, bool)
 }
 
 type zeroValueOptimizer struct{}
@@ -15,8 +21,9 @@ func NewZeroValueOptimizer() Optimizer {
 	return zeroValueOptimizer{}
 }
 
-func (zeroValueOptimizer) determineOptimalValues(freeVariables *freeVariables) uint32 {
-	return uint32(0)
+// The zero values are not searched for, so there is nothing to stop
+func (zeroValueOptimizer) determineOptimalValues(ctx context.Context, freeVariables *freeVariables) (utils.BitVector, bool) {
+	return utils.NewBitVector(len(freeVariables.indexes)), true
 }
 
 type bruteForceOptimizer struct{}
@@ -25,57 +32,142 @@ func NewBruteForceOptimizer() Optimizer {
 	return bruteForceOptimizer{}
 }
 
-func (bruteForceOptimizer) determineOptimalValues(freeVariables *freeVariables) uint32 {
+func (bruteForceOptimizer) determineOptimalValues(ctx context.Context, freeVariables *freeVariables) (utils.BitVector, bool) {
+	optimalValues := utils.NewBitVector(len(freeVariables.indexes))
 	if len(freeVariables.indexes) == 0 || len(freeVariables.affectedRows) == 0 {
-		return uint32(0)
+		return optimalValues, true
+	}
+
+	// The values are the bits of a counter, which cannot count through the combinations of 64 or more free variables,
+	// those would not finish anyway, so they are left to the branch-and-bound optimizer, which starts from the solution of flipping single variables
+	if len(freeVariables.indexes) >= 64 {
+		return branchAndBoundOptimizer{}.determineOptimalValues(ctx, freeVariables)
 	}
 
 	affectedSolution := getAffectedSolution(freeVariables)
+	affectedColumns := getAffectedColumns(freeVariables)
+	variableCosts, rowCosts := getCosts(freeVariables)
+
+	values := uint64(0)
+	result := 0
+	for t := range freeVariables.affectedRows {
+		if affectedSolution.TestBit(t) {
+			result += getCost(rowCosts, t)
+		}
+	}
 
-	optimalValues := uint32(0)
-	optimalResult := calculateResultForValues(freeVariables, affectedSolution, optimalValues)
+	// The values of the free variables are enumerated in Gray-code order, so each step flips a single free variable,
+	// which changes the solution of the affected rows with a single XOR
+	optimalCounter := values
+	optimalResult := result
 
-	for values := uint32(1); values < 1<<len(freeVariables.indexes); values++ {
-		result := calculateResultForValues(freeVariables, affectedSolution, values)
-		if optimalResult > result {
-			optimalValues = values
+	finished := true
+	for step := uint64(1); step < 1<<len(freeVariables.indexes); step++ {
+		if step%contextCheckInterval == 0 && ctx.Err() != nil {
+			finished = false
+			break
+		}
+
+		i := bits.TrailingZeros64(step)
+		values ^= 1 << i
+
+		if values&(1<<i) != 0 {
+			result += getCost(variableCosts, i)
+		} else {
+			result -= getCost(variableCosts, i)
+		}
+		result += flipAffectedRows(affectedSolution, affectedColumns[i], rowCosts)
+
+		// The ties are broken by the lowest counter, so the same values are chosen as when counting upwards
+		if optimalResult > result || optimalResult == result && optimalCounter > values {
+			optimalCounter = values
 			optimalResult = result
 		}
 	}
 
-	return optimalValues
+	optimalValues[0] = optimalCounter
+	return optimalValues, finished
 }
 
-func getAffectedSolution(freeVariables *freeVariables) (result uint32) {
+func getAffectedSolution(freeVariables *freeVariables) utils.BitVector {
+	result := utils.NewBitVector(len(freeVariables.affectedRows))
 	for i, affectedRow := range freeVariables.affectedRows {
-		if utils.TestBit(affectedRow, constantRow) {
-			result = utils.SetBit(result, uint8(i))
+		if affectedRow.TestBit(freeVariables.constantRow) {
+			result.SetBit(i)
+		}
+	}
+
+	return result
+}
+
+// Returns the affected rows that each free variable appears in
+func getAffectedColumns(freeVariables *freeVariables) []utils.BitVector {
+	// The columns share a single allocation, as they are created for every optimization
+	columnLength := len(utils.NewBitVector(len(freeVariables.affectedRows)))
+	words := make(utils.BitVector, len(freeVariables.indexes)*columnLength)
+
+	columns := make([]utils.BitVector, len(freeVariables.indexes))
+	for i, index := range freeVariables.indexes {
+		columns[i] = words[i*columnLength : (i+1)*columnLength]
+		for t, affectedRow := range freeVariables.affectedRows {
+			if affectedRow.TestBit(index) {
+				columns[i].SetBit(t)
+			}
 		}
 	}
 
-	return
+	return columns
 }
 
-func calculateResultForValues(freeVariables *freeVariables, affectedSolution uint32, values uint32) (result uint8) {
-	// Do back-substitution according to the current values
-	for i := uint8(0); i < uint8(len(freeVariables.indexes)); i++ {
-		if !utils.TestBit(values, i) {
+// Returns the cost of clicking each free variable, and the cost of the pivot of each affected row,
+// both are nil if every click costs 1
+func getCosts(freeVariables *freeVariables) ([]int, []int) {
+	if len(freeVariables.costs) == 0 {
+		return nil, nil
+	}
+
+	variableCosts := make([]int, len(freeVariables.indexes))
+	for i, index := range freeVariables.indexes {
+		variableCosts[i] = freeVariables.costs[index]
+	}
+
+	rowCosts := make([]int, len(freeVariables.affectedRows))
+	for t, affectedRow := range freeVariables.affectedRows {
+		rowCosts[t] = freeVariables.costs[affectedRow.TrailingZeros()]
+	}
+
+	return variableCosts, rowCosts
+}
+
+func getCost(costs []int, i int) int {
+	if costs == nil {
+		return 1
+	}
+
+	return costs[i]
+}
+
+// Flips the affected rows of the column in the solution, and returns the change in the cost of the "clicks" needed for them
+func flipAffectedRows(affectedSolution utils.BitVector, column utils.BitVector, rowCosts []int) (change int) {
+	for w, word := range column {
+		clicked := word & affectedSolution[w]
+		affectedSolution[w] ^= word
+
+		// With the same cost for every click, the newly needed clicks are added and the ones no longer needed are taken away
+		if rowCosts == nil {
+			change += bits.OnesCount64(word) - 2*bits.OnesCount64(clicked)
 			continue
 		}
 
-		for t := uint8(0); t < uint8(len(freeVariables.affectedRows)); t++ {
-			if utils.TestBit(freeVariables.affectedRows[t], freeVariables.indexes[i]) {
-				affectedSolution = utils.FlipBit(affectedSolution, t)
+		for ; word != 0; word &= word - 1 {
+			t := w*64 + bits.TrailingZeros64(word)
+			if clicked&(1<<(t%64)) != 0 {
+				change -= rowCosts[t]
+			} else {
+				change += rowCosts[t]
 			}
 		}
 	}
 
-	// Calculate the amount of "clicks" required in case of this solution
-	// The "clicks" needed for the free variables
-	result = uint8(bits.OnesCount32(values))
-
-	// The "clicks" needed for the other affected variables
-	result += uint8(bits.OnesCount32(affectedSolution))
-
-	return result
+	return change
 }
@@ -1,17 +1,22 @@
 package solver
 
-import "testing"
+import (
+	"context"
+	"math/bits"
+	"server/utils"
+	"testing"
+)
 
 func TestZeroValueOptimizer(t *testing.T) {
 	// Arrange
-	freeVariables := freeVariables{indexes: make([]uint8, 0), affectedRows: make([]uint32, 0)}
+	freeVariables := freeVariables{indexes: make([]int, 0), affectedRows: make([]utils.BitVector, 0), constantRow: 25}
 
 	// Act
 	optimizer := NewZeroValueOptimizer()
-	result := optimizer.determineOptimalValues(&freeVariables)
+	result, _ := optimizer.determineOptimalValues(context.Background(), &freeVariables)
 
 	// Assert
-	if result != 0b0 {
+	if !result.IsZero() {
 		t.Errorf("Incorrect result: expected 0, got %v", result)
 	}
 }
@@ -19,39 +24,40 @@ func TestZeroValueOptimizer(t *testing.T) {
 func TestBruteForceOptimizer(t *testing.T) {
 	testCases := []struct {
 		name           string
-		indexes        []uint8
-		affectedRows   []uint32
-		expectedResult uint32
+		indexes        []int
+		affectedRows   []utils.BitVector
+		costs          []int
+		expectedResult utils.BitVector
 	}{
 		{
 			name:           "No free variables",
-			indexes:        make([]uint8, 0),
-			affectedRows:   make([]uint32, 0),
-			expectedResult: 0b0,
+			indexes:        make([]int, 0),
+			affectedRows:   make([]utils.BitVector, 0),
+			expectedResult: utils.BitVector{0b0},
 		},
 		// Free state -> Affected state (free + affected = total) | Best
 		// 0          -> 0 0            (0 + 0 = 0)                 *
 		// 1          -> 1 1            (1 + 2 = 3)
 		{
 			name:    "Single free variable, expect 0",
-			indexes: []uint8{15},
-			affectedRows: []uint32{
-				0b00_0000_0000_1000_0000_0000_0001,
-				0b00_0000_0000_1000_0000_0000_0010,
+			indexes: []int{15},
+			affectedRows: []utils.BitVector{
+				{0b00_0000_0000_1000_0000_0000_0001},
+				{0b00_0000_0000_1000_0000_0000_0010},
 			},
-			expectedResult: 0b0,
+			expectedResult: utils.BitVector{0b0},
 		},
 		// Free state -> Affected state (free + affected = total) | Best
 		// 0          -> 1 1            (0 + 2 = 2)
 		// 1          -> 0 0            (1 + 0 = 1)                 *
 		{
 			name:    "Single free variable, expect 1",
-			indexes: []uint8{9},
-			affectedRows: []uint32{
-				0b10_0000_0000_0000_0010_0000_0001,
-				0b10_0000_0000_0000_0010_0000_0010,
+			indexes: []int{9},
+			affectedRows: []utils.BitVector{
+				{0b10_0000_0000_0000_0010_0000_0001},
+				{0b10_0000_0000_0000_0010_0000_0010},
 			},
-			expectedResult: 0b1,
+			expectedResult: utils.BitVector{0b1},
 		},
 		// Free state -> Affected state (free + affected = total) | Best
 		// 0 0        -> 1 0            (0 + 1 = 1)                 *
@@ -60,12 +66,12 @@ func TestBruteForceOptimizer(t *testing.T) {
 		// 1 1        -> 0 0            (2 + 0 = 2)
 		{
 			name:    "Two free variables, expect (0, 0)",
-			indexes: []uint8{15, 19},
-			affectedRows: []uint32{
-				0b10_0000_0000_1000_0000_0000_0001,
-				0b00_0000_1000_1000_0000_0000_0010,
+			indexes: []int{15, 19},
+			affectedRows: []utils.BitVector{
+				{0b10_0000_0000_1000_0000_0000_0001},
+				{0b00_0000_1000_1000_0000_0000_0010},
 			},
-			expectedResult: 0b0_0,
+			expectedResult: utils.BitVector{0b0_0},
 		},
 		// Free state -> Affected state (free + affected = total) | Best
 		// 0 0        -> 1 1 1 1 1      (0 + 5 = 5)
@@ -74,15 +80,15 @@ func TestBruteForceOptimizer(t *testing.T) {
 		// 1 1        -> 0 0 1 1 1      (2 + 3 = 5)
 		{
 			name:    "Two free variables, expect (0, 1)",
-			indexes: []uint8{19, 15},
-			affectedRows: []uint32{
-				0b10_0000_0000_1000_0000_0000_0001,
-				0b10_0000_0000_1000_0000_0000_0010,
-				0b10_0000_1000_1000_0000_0000_0100,
-				0b10_0000_1000_1000_0000_0000_1000,
-				0b10_0000_1000_1000_0000_0001_0000,
+			indexes: []int{19, 15},
+			affectedRows: []utils.BitVector{
+				{0b10_0000_0000_1000_0000_0000_0001},
+				{0b10_0000_0000_1000_0000_0000_0010},
+				{0b10_0000_1000_1000_0000_0000_0100},
+				{0b10_0000_1000_1000_0000_0000_1000},
+				{0b10_0000_1000_1000_0000_0001_0000},
 			},
-			expectedResult: 0b1_0,
+			expectedResult: utils.BitVector{0b1_0},
 		},
 		// Free state -> Affected state (free + affected = total) | Best
 		// 0 0 0      -> 1 1 0 1 1      (0 + 4 = 4)
@@ -95,37 +101,298 @@ func TestBruteForceOptimizer(t *testing.T) {
 		// 1 1 1      -> 1 1 1 1 0      (3 + 4 = 7)
 		{
 			name:    "Three free variables, expect (1, 0, 1)",
-			indexes: []uint8{20, 21, 22},
-			affectedRows: []uint32{
-				0b10_0011_0000_0000_0000_0000_0001,
-				0b10_0011_0000_0000_0000_0000_0010,
-				0b00_0010_0000_0000_0000_0000_0100,
-				0b10_0110_0000_0000_0000_0000_1000,
-				0b10_0100_0000_0000_0000_0001_0000,
+			indexes: []int{20, 21, 22},
+			affectedRows: []utils.BitVector{
+				{0b10_0011_0000_0000_0000_0000_0001},
+				{0b10_0011_0000_0000_0000_0000_0010},
+				{0b00_0010_0000_0000_0000_0000_0100},
+				{0b10_0110_0000_0000_0000_0000_1000},
+				{0b10_0100_0000_0000_0000_0001_0000},
 			},
-			expectedResult: 0b1_0_1,
+			expectedResult: utils.BitVector{0b1_0_1},
+		},
+		// Free state -> Affected state (free + affected = total cost) | Best
+		// 0 0        -> 1 0            (0 + 10 = 10)
+		// 1 0        -> 0 1            (1 + 1 = 2)                      *
+		// 0 1        -> 1 1            (1 + 11 = 12)
+		// 1 1        -> 0 0            (2 + 0 = 2)
+		{
+			name:    "Two free variables with costs, expect (1, 0)",
+			indexes: []int{15, 19},
+			affectedRows: []utils.BitVector{
+				{0b10_0000_0000_1000_0000_0000_0001},
+				{0b00_0000_1000_1000_0000_0000_0010},
+			},
+			costs:          []int{10, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+			expectedResult: utils.BitVector{0b0_1},
+		},
+		// Free state -> Affected state (free + affected = total cost) | Best
+		// 0 0        -> 1 0            (0 + 10 = 10)
+		// 1 0        -> 0 1            (5 + 4 = 9)
+		// 0 1        -> 1 1            (1 + 14 = 15)
+		// 1 1        -> 0 0            (6 + 0 = 6)                      *
+		{
+			name:    "Two free variables with costs, expect (1, 1)",
+			indexes: []int{15, 19},
+			affectedRows: []utils.BitVector{
+				{0b10_0000_0000_1000_0000_0000_0001},
+				{0b00_0000_1000_1000_0000_0000_0010},
+			},
+			costs:          []int{10, 4, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 5, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+			expectedResult: utils.BitVector{0b1_1},
 		},
 		{
 			name:           "Multiple free variables, no affected rows",
-			indexes:        []uint8{1, 3, 5, 7, 9, 11},
-			affectedRows:   make([]uint32, 0),
-			expectedResult: 0b0_0_0_0_0_0,
+			indexes:        []int{1, 3, 5, 7, 9, 11},
+			affectedRows:   make([]utils.BitVector, 0),
+			expectedResult: utils.BitVector{0b0_0_0_0_0_0},
 		},
 	}
 
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
 			// Arrange
-			freeVariables := freeVariables{indexes: testCase.indexes, affectedRows: testCase.affectedRows}
+			freeVariables := freeVariables{indexes: testCase.indexes, affectedRows: testCase.affectedRows, constantRow: 25, costs: testCase.costs}
 
 			// Act
 			optimizer := NewBruteForceOptimizer()
-			result := optimizer.determineOptimalValues(&freeVariables)
+			result, _ := optimizer.determineOptimalValues(context.Background(), &freeVariables)
 
 			// Assert
-			if result != testCase.expectedResult {
+			if !result.Equal(testCase.expectedResult) {
 				t.Errorf("Incorrect result: expected %v, got %v", testCase.expectedResult, result)
 			}
 		})
 	}
 }
+
+// The brute-force optimizer before the Gray-code enumeration, which calculates every candidate from scratch,
+// kept to compare the results and the speed of the two
+type counterOptimizer struct{}
+
+func (counterOptimizer) determineOptimalValues(ctx context.Context, freeVariables *freeVariables) (utils.BitVector, bool) {
+	optimalValues := utils.NewBitVector(len(freeVariables.indexes))
+	if len(freeVariables.indexes) == 0 || len(freeVariables.affectedRows) == 0 {
+		return optimalValues, true
+	}
+
+	affectedSolution := getAffectedSolution(freeVariables)
+
+	optimalCounter := uint64(0)
+	optimalResult := calculateResultForValues(freeVariables, affectedSolution, optimalCounter)
+
+	for values := uint64(1); values < 1<<len(freeVariables.indexes); values++ {
+		result := calculateResultForValues(freeVariables, affectedSolution, values)
+		if optimalResult > result {
+			optimalCounter = values
+			optimalResult = result
+		}
+	}
+
+	optimalValues[0] = optimalCounter
+	return optimalValues, true
+}
+
+func calculateResultForValues(freeVariables *freeVariables, affectedSolution utils.BitVector, values uint64) (result int) {
+	affectedSolution = affectedSolution.Clone()
+
+	// Do back-substitution according to the current values
+	for i, index := range freeVariables.indexes {
+		if values&(1<<i) == 0 {
+			continue
+		}
+
+		for t, affectedRow := range freeVariables.affectedRows {
+			if affectedRow.TestBit(index) {
+				affectedSolution.FlipBit(t)
+			}
+		}
+	}
+
+	if len(freeVariables.costs) > 0 {
+		return calculateCostForValues(freeVariables, affectedSolution, values)
+	}
+
+	// The "clicks" needed for the free variables and the other affected variables
+	return bits.OnesCount64(values) + affectedSolution.OnesCount()
+}
+
+func calculateCostForValues(freeVariables *freeVariables, affectedSolution utils.BitVector, values uint64) (result int) {
+	for i, index := range freeVariables.indexes {
+		if values&(1<<i) != 0 {
+			result += freeVariables.costs[index]
+		}
+	}
+
+	for t, affectedRow := range freeVariables.affectedRows {
+		if affectedSolution.TestBit(t) {
+			result += freeVariables.costs[affectedRow.TrailingZeros()]
+		}
+	}
+
+	return result
+}
+
+// Returns the free variables of a board after the elimination, as the free variable fixer finds them
+func getEliminatedFreeVariables(shape Shape, board utils.BitVector, costs []int) *freeVariables {
+	augmentedMatrix := getAugmentedMatrix(shape, board, Options{})
+	_, finalRow := NewGaussianEliminator().gaussianEliminate(augmentedMatrix)
+
+	freeVariables := findFreeVariables(augmentedMatrix, finalRow)
+	freeVariables.costs = costs
+	return &freeVariables
+}
+
+// Returns a board that can be solved, made by clicking every third cell, and the given cell too
+func getPatternBoard(shape Shape, cell int) utils.BitVector {
+	clicks := utils.NewBitVector(shape.CellCount())
+	for i := 0; i < shape.CellCount(); i += 3 {
+		clicks.SetBit(i)
+	}
+	clicks.FlipBit(cell)
+
+	return applyClicks(shape, utils.NewBitVector(shape.CellCount()), clicks)
+}
+
+func TestBruteForceOptimizerWithManyFreeVariables(t *testing.T) {
+	// Arrange
+	// Only the first cell has a target, so 69 of the clicks are free, and clicking the first cell costs the most
+	shape := Shape{RowCount: 1, ColumnCount: 70}
+	board := utils.NewBitVector(shape.CellCount())
+	board.SetBit(0)
+	targetMask := utils.NewBitVector(shape.CellCount())
+	targetMask.SetBit(0)
+	costs := make([]int, shape.CellCount())
+	for i := range costs {
+		costs[i] = 1
+	}
+	costs[0] = 100
+	options := Options{TargetMask: targetMask, Costs: costs}
+
+	solver := NewBoardSolver(NewGaussianEliminator(), NewFreeVariableFixer(NewBruteForceOptimizer()))
+
+	// Act
+	solvability, solution, optimal := solver.SolveBoard(context.Background(), shape, board, options)
+
+	// Assert
+	if solvability != Solvable || !reachesTarget(shape, board, options, solution) {
+		t.Fatalf("Incorrect result: %v %b does not reach the target", solvability, solution)
+	}
+
+	if cost := totalCost(shape, options, solution); cost != 1 || !optimal {
+		t.Errorf("Incorrect result: expected an optimal solution with cost 1, got %b with cost %v (optimal: %v)", solution, cost, optimal)
+	}
+}
+
+func TestBruteForceOptimizerMatchesCounter(t *testing.T) {
+	testCases := []struct {
+		name  string
+		shape Shape
+		costs bool
+	}{
+		{
+			name:  "4x4 board",
+			shape: Shape{RowCount: 4, ColumnCount: 4},
+		},
+		{
+			name:  "9x9 board",
+			shape: Shape{RowCount: 9, ColumnCount: 9},
+		},
+		{
+			name:  "16x16 board",
+			shape: Shape{RowCount: 16, ColumnCount: 16},
+		},
+		{
+			name:  "6x6 torus",
+			shape: Shape{RowCount: 6, ColumnCount: 6, Topology: ToroidalTopology},
+		},
+		{
+			name:  "9x9 board with costs",
+			shape: Shape{RowCount: 9, ColumnCount: 9},
+			costs: true,
+		},
+		{
+			name:  "6x6 torus with costs",
+			shape: Shape{RowCount: 6, ColumnCount: 6, Topology: ToroidalTopology},
+			costs: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Arrange
+			var costs []int
+			if testCase.costs {
+				costs = make([]int, testCase.shape.CellCount())
+				for i := range costs {
+					costs[i] = 1 + i*7%5
+				}
+			}
+
+			// Different boards give different constants, with the same coefficients
+			for seed := 0; seed < 3; seed++ {
+				board := getPatternBoard(testCase.shape, seed)
+				freeVariables := getEliminatedFreeVariables(testCase.shape, board, costs)
+
+				// Act
+				expected, _ := counterOptimizer{}.determineOptimalValues(context.Background(), freeVariables)
+				result, _ := NewBruteForceOptimizer().determineOptimalValues(context.Background(), freeVariables)
+
+				// Assert
+				if !result.Equal(expected) {
+					t.Errorf("Incorrect result for board %b: expected %b, got %b", board, expected, result)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkBruteForceOptimizer(b *testing.B) {
+	shapes := []struct {
+		name  string
+		shape Shape
+	}{
+		{
+			name:  "5x5 board, 2 free variables",
+			shape: DefaultShape,
+		},
+		{
+			name:  "9x9 board, 8 free variables",
+			shape: Shape{RowCount: 9, ColumnCount: 9},
+		},
+		{
+			name:  "19x19 board, 16 free variables",
+			shape: Shape{RowCount: 19, ColumnCount: 19},
+		},
+		{
+			name:  "12x12 torus, 16 free variables",
+			shape: Shape{RowCount: 12, ColumnCount: 12, Topology: ToroidalTopology},
+		},
+	}
+
+	optimizers := []struct {
+		name      string
+		optimizer Optimizer
+	}{
+		{
+			name:      "Counter",
+			optimizer: counterOptimizer{},
+		},
+		{
+			name:      "Gray code",
+			optimizer: NewBruteForceOptimizer(),
+		},
+	}
+
+	for _, shape := range shapes {
+		freeVariables := getEliminatedFreeVariables(shape.shape, getPatternBoard(shape.shape, 0), nil)
+
+		for _, optimizer := range optimizers {
+			b.Run(shape.name+"/"+optimizer.name, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					optimizer.optimizer.determineOptimalValues(context.Background(), freeVariables)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package solver
+
+import (
+	"fmt"
+	"server/utils"
+)
+
+// The upper limit on the cost of a click, to keep the total cost of a solution from overflowing
+const MaxClickCost = 1 << 20
+
+// Options are the additional requirements of solving a board,
+// the zero value asks for the fewest clicks that turn off all lights
+type Options struct {
+	// The state the board has to end up in, all lights off if empty
+	Target utils.BitVector
+	// The cells that have to match the target, the rest can end up in any state, all cells have to match if empty
+	TargetMask utils.BitVector
+	// The cost of clicking each cell, the solution with the lowest total cost is chosen,
+	// every click costs the same if empty
+	Costs []int
+	// The cells that must not be clicked, like locked tiles
+	ForbiddenClicks utils.BitVector
+	// The cells that must be clicked
+	MandatoryClicks utils.BitVector
+	// The rule that chooses between the solutions with the same lowest cost
+	TieBreaking TieBreaking
+}
+
+func (o Options) Validate(shape Shape) error {
+	if err := validateCellSet(shape, o.Target); err != nil {
+		return fmt.Errorf("invalid target: %w", err)
+	}
+
+	if err := validateCellSet(shape, o.TargetMask); err != nil {
+		return fmt.Errorf("invalid target mask: %w", err)
+	}
+
+	if err := validateCellSet(shape, o.ForbiddenClicks); err != nil {
+		return fmt.Errorf("invalid forbidden clicks: %w", err)
+	}
+
+	if err := validateCellSet(shape, o.MandatoryClicks); err != nil {
+		return fmt.Errorf("invalid mandatory clicks: %w", err)
+	}
+
+	for i := 0; i < shape.CellCount(); i++ {
+		if o.isForbidden(i) && o.isMandatory(i) {
+			return fmt.Errorf("cell %v cannot be both forbidden and mandatory to click", i)
+		}
+	}
+
+	if err := o.TieBreaking.Validate(); err != nil {
+		return err
+	}
+
+	if len(o.Costs) > 0 {
+		return o.validateCosts(shape)
+	}
+
+	return nil
+}
+
+func (o Options) validateCosts(shape Shape) error {
+	if len(o.Costs) != shape.CellCount() {
+		return fmt.Errorf("there has to be a cost for each of the %v cells", shape.CellCount())
+	}
+
+	for i, cost := range o.Costs {
+		if cost < 0 || cost > MaxClickCost {
+			return fmt.Errorf("the cost of cell %v has to be between 0 and %v", i, MaxClickCost)
+		}
+	}
+
+	return nil
+}
+
+// Checks that only the existing cells of the board are set in the cell set
+func validateCellSet(shape Shape, cellSet utils.BitVector) error {
+	for i := 0; i < len(cellSet)*64; i++ {
+		if !cellSet.TestBit(i) {
+			continue
+		}
+
+		if i >= shape.CellCount() || !shape.HasCell(i) {
+			return fmt.Errorf("cell %v is not on the board", i)
+		}
+	}
+
+	return nil
+}
+
+// Returns the requirements on the variables, which belong to the existing cells of the board
+func (o Options) variableConstraints(shape Shape) variableConstraints {
+	cells := shape.Cells()
+
+	var constraints variableConstraints
+	if len(o.Costs) > 0 {
+		constraints.costs = make([]int, len(cells))
+		for i, cell := range cells {
+			constraints.costs[i] = o.Costs[cell]
+		}
+	}
+
+	if !o.hasClickConstraints() {
+		return constraints
+	}
+
+	constraints.fixed = utils.NewBitVector(len(cells))
+	constraints.values = utils.NewBitVector(len(cells))
+	for i, cell := range cells {
+		if o.isForbidden(cell) {
+			constraints.fixed.SetBit(i)
+		}
+
+		if o.isMandatory(cell) {
+			constraints.fixed.SetBit(i)
+			constraints.values.SetBit(i)
+		}
+	}
+
+	return constraints
+}
+
+// Reports whether any of the clicks are forbidden or mandatory
+func (o Options) hasClickConstraints() bool {
+	return !o.ForbiddenClicks.IsZero() || !o.MandatoryClicks.IsZero()
+}
+
+func (o Options) isForbidden(cell int) bool {
+	return cell < len(o.ForbiddenClicks)*64 && o.ForbiddenClicks.TestBit(cell)
+}
+
+func (o Options) isMandatory(cell int) bool {
+	return cell < len(o.MandatoryClicks)*64 && o.MandatoryClicks.TestBit(cell)
+}
+
+// Reports whether the final state of the cell has to match the target
+func (o Options) hasTarget(cell int) bool {
+	return len(o.TargetMask) == 0 || cell < len(o.TargetMask)*64 && o.TargetMask.TestBit(cell)
+}
+
+// Returns the cells that have to be toggled to get from the board to the target
+func (o Options) toggledCells(shape Shape, board utils.BitVector) utils.BitVector {
+	cellCount := shape.CellCount()
+
+	toggledCells := utils.NewBitVector(cellCount)
+	copy(toggledCells, board)
+
+	target := utils.NewBitVector(cellCount)
+	copy(target, o.Target)
+	toggledCells.Xor(target)
+
+	return toggledCells
+}
@@ -0,0 +1,124 @@
+package solver
+
+import (
+	"server/utils"
+	"testing"
+)
+
+func TestOptionsValidation(t *testing.T) {
+	testCases := []struct {
+		name          string
+		shape         Shape
+		options       Options
+		expectedValid bool
+	}{
+		{
+			name:          "Default options",
+			shape:         DefaultShape,
+			options:       Options{},
+			expectedValid: true,
+		},
+		{
+			name:          "Target",
+			shape:         DefaultShape,
+			options:       Options{Target: utils.BitVector{0b11111_00000_11111_00000_11111}},
+			expectedValid: true,
+		},
+		{
+			name:          "Target with cells outside the board",
+			shape:         DefaultShape,
+			options:       Options{Target: utils.BitVector{0b1_00000_00000_00000_00000_00000}},
+			expectedValid: false,
+		},
+		{
+			name:          "Target with missing cells",
+			shape:         crossBoard,
+			options:       Options{Target: utils.BitVector{0b000_000_001}},
+			expectedValid: false,
+		},
+		{
+			name:          "Target mask",
+			shape:         DefaultShape,
+			options:       Options{TargetMask: utils.BitVector{0b00000_00100_01110_00100_00000}},
+			expectedValid: true,
+		},
+		{
+			name:          "Target mask with cells outside the board",
+			shape:         Shape{RowCount: 2, ColumnCount: 2},
+			options:       Options{TargetMask: utils.BitVector{0b1_0000}},
+			expectedValid: false,
+		},
+		{
+			name:          "Costs",
+			shape:         Shape{RowCount: 2, ColumnCount: 2},
+			options:       Options{Costs: []int{0, 1, 2, MaxClickCost}},
+			expectedValid: true,
+		},
+		{
+			name:          "Missing cost",
+			shape:         Shape{RowCount: 2, ColumnCount: 2},
+			options:       Options{Costs: []int{1, 1, 1}},
+			expectedValid: false,
+		},
+		{
+			name:          "Negative cost",
+			shape:         Shape{RowCount: 2, ColumnCount: 2},
+			options:       Options{Costs: []int{1, -1, 1, 1}},
+			expectedValid: false,
+		},
+		{
+			name:          "Too large cost",
+			shape:         Shape{RowCount: 2, ColumnCount: 2},
+			options:       Options{Costs: []int{1, 1, MaxClickCost + 1, 1}},
+			expectedValid: false,
+		},
+		{
+			name:          "Forbidden and mandatory clicks",
+			shape:         DefaultShape,
+			options:       Options{ForbiddenClicks: utils.BitVector{0b00000_00000_00100_00000_00000}, MandatoryClicks: utils.BitVector{0b00001}},
+			expectedValid: true,
+		},
+		{
+			name:          "Forbidden click on a missing cell",
+			shape:         crossBoard,
+			options:       Options{ForbiddenClicks: utils.BitVector{0b100_000_000}},
+			expectedValid: false,
+		},
+		{
+			name:          "Mandatory click outside the board",
+			shape:         Shape{RowCount: 2, ColumnCount: 2},
+			options:       Options{MandatoryClicks: utils.BitVector{0b1_0000}},
+			expectedValid: false,
+		},
+		{
+			name:          "Cell that is both forbidden and mandatory",
+			shape:         Shape{RowCount: 2, ColumnCount: 2},
+			options:       Options{ForbiddenClicks: utils.BitVector{0b0110}, MandatoryClicks: utils.BitVector{0b0100}},
+			expectedValid: false,
+		},
+		{
+			name:          "Tie-breaking rule",
+			shape:         DefaultShape,
+			options:       Options{TieBreaking: CentreTieBreaking},
+			expectedValid: true,
+		},
+		{
+			name:          "Unknown tie-breaking rule",
+			shape:         DefaultShape,
+			options:       Options{TieBreaking: CentreTieBreaking + 1},
+			expectedValid: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Act
+			err := testCase.options.Validate(testCase.shape)
+
+			// Assert
+			if (err == nil) != testCase.expectedValid {
+				t.Errorf("Incorrect result: expected valid: %v, got error: %v", testCase.expectedValid, err)
+			}
+		})
+	}
+}
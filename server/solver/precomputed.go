@@ -0,0 +1,143 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"server/gf2"
+	"server/utils"
+	"sync"
+)
+
+// The number of factorizations kept by the precomputed solver, the cache is emptied once it fills up
+const maxCachedFactorizations = 256
+
+// The elimination of the equations of a shape, which only depends on the coefficients, so it can be reused for every board
+type factorization struct {
+	// The coefficients in reduced row echelon form, with an empty constant column, the free columns span the kernel
+	reducedMatrix []utils.BitVector
+	rank          int
+	// The cells of the board that make up the constant of each row, the rows up to the rank form the pseudo-inverse,
+	// and the rest are the checks that have to come out even for the board to be solvable
+	combinations []utils.BitVector
+	// The part of the constants that comes from the mandatory clicks
+	mandatoryConstants utils.BitVector
+}
+
+type precomputedSolver struct {
+	freeVariableFixer FreeVariableFixer
+
+	mutex          sync.Mutex
+	factorizations map[string]*factorization
+}
+
+// NewPrecomputedSolver returns a board solver that eliminates the equations only once for each shape, and gives the same results as the board solver
+func NewPrecomputedSolver(freeVariableFixer FreeVariableFixer) BoardSolver {
+	return &precomputedSolver{freeVariableFixer: freeVariableFixer, factorizations: make(map[string]*factorization)}
+}
+
+func (s *precomputedSolver) SolveBoard(ctx context.Context, shape Shape, board utils.BitVector, options Options) (Solvability, utils.BitVector, bool) {
+	f := s.getFactorization(shape, options)
+
+	// The constants of the eliminated equations, the ones after the rank have to be zero
+	toggledCells := options.toggledCells(shape, board)
+	constants := f.getConstants(toggledCells)
+	if !f.isSolvable(constants) {
+		// The same cells have to be toggled without the constraints, only the factorization is different
+		return determineUnsolvabilityWith(options, func(options Options) bool {
+			f := s.getFactorization(shape, options)
+			return f.isSolvable(f.getConstants(toggledCells))
+		}), nil, true
+	}
+
+	augmentedMatrix := make([]utils.BitVector, len(f.reducedMatrix))
+	constantRow := len(f.reducedMatrix)
+	for i, row := range f.reducedMatrix {
+		augmentedMatrix[i] = row.Clone()
+		if constants.TestBit(i) {
+			augmentedMatrix[i].SetBit(constantRow)
+		}
+	}
+
+	return solveEliminatedEquations(ctx, s.freeVariableFixer, shape, augmentedMatrix, f.rank, options)
+}
+
+// Returns the factorization of the equations, eliminating them on the first use of the shape with the same options
+func (s *precomputedSolver) getFactorization(shape Shape, options Options) *factorization {
+	// Only the options that change the coefficients or the constants of the mandatory clicks matter
+	key := fmt.Sprintf("%v|%v|%v|%v", shape, options.TargetMask, options.ForbiddenClicks, options.MandatoryClicks)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if f, exists := s.factorizations[key]; exists {
+		return f
+	}
+
+	if len(s.factorizations) >= maxCachedFactorizations {
+		s.factorizations = make(map[string]*factorization)
+	}
+
+	f := factorize(shape, options)
+	s.factorizations[key] = f
+	return f
+}
+
+func factorize(shape Shape, options Options) *factorization {
+	// The constants of an empty board without a target only come from the mandatory clicks
+	options.Target = nil
+	augmentedMatrix := getAugmentedMatrix(shape, utils.NewBitVector(shape.CellCount()), options)
+	variableCount := len(augmentedMatrix)
+	constantRow := variableCount
+
+	// The rows are eliminated the same way as the board solver eliminates them
+	rows := extendWithUnitVectors(augmentedMatrix)
+	rank := gf2.TransformToRowEchelon(rows, variableCount)
+	gf2.BackSubstitution(rows, rank)
+
+	f := &factorization{
+		reducedMatrix:      make([]utils.BitVector, variableCount),
+		rank:               rank,
+		combinations:       make([]utils.BitVector, variableCount),
+		mandatoryConstants: utils.NewBitVector(variableCount),
+	}
+
+	for i, row := range rows {
+		f.reducedMatrix[i] = utils.NewBitVector(variableCount + 1)
+		for j := 0; j < variableCount; j++ {
+			if row.TestBit(j) {
+				f.reducedMatrix[i].SetBit(j)
+			}
+		}
+
+		f.combinations[i] = getRowCombination(shape, options, row, constantRow)
+
+		if row.TestBit(constantRow) {
+			f.mandatoryConstants.SetBit(i)
+		}
+	}
+
+	return f
+}
+
+// Returns the constants of the eliminated equations, one bit for each row
+func (f *factorization) getConstants(toggledCells utils.BitVector) utils.BitVector {
+	constants := f.mandatoryConstants.Clone()
+	for i, combination := range f.combinations {
+		if combination.Dot(toggledCells) {
+			constants.FlipBit(i)
+		}
+	}
+
+	return constants
+}
+
+// Reports whether all the checks after the rank come out even
+func (f *factorization) isSolvable(constants utils.BitVector) bool {
+	for i := f.rank; i < len(f.combinations); i++ {
+		if constants.TestBit(i) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,164 @@
+package solver
+
+import (
+	"context"
+	"reflect"
+	"server/utils"
+	"testing"
+)
+
+func TestPrecomputedSolverMatchesBoardSolver(t *testing.T) {
+	testCases := []struct {
+		name    string
+		shape   Shape
+		options Options
+	}{
+		{
+			name:  "4x4 board",
+			shape: Shape{RowCount: 4, ColumnCount: 4},
+		},
+		{
+			name:  "3x3 board",
+			shape: Shape{RowCount: 3, ColumnCount: 3},
+		},
+		{
+			name:  "3x3 torus",
+			shape: Shape{RowCount: 3, ColumnCount: 3, Topology: ToroidalTopology},
+		},
+		{
+			name:  "Hexagonal board",
+			shape: Shape{RowCount: 3, ColumnCount: 4, Grid: HexagonalGrid},
+		},
+		{
+			name:  "Merlin's Magic Square",
+			shape: merlinsMagicSquare,
+		},
+		{
+			name:  "Masked board",
+			shape: crossBoard,
+		},
+		{
+			name:    "Target",
+			shape:   Shape{RowCount: 4, ColumnCount: 4},
+			options: Options{Target: utils.BitVector{0b1001_0000_0000_1001}},
+		},
+		{
+			name:    "Don't-care cells",
+			shape:   Shape{RowCount: 4, ColumnCount: 4},
+			options: Options{Target: utils.BitVector{0b0000_0110_0000_0000}, TargetMask: utils.BitVector{0b1111_1111_0111_1110}},
+		},
+		{
+			name:    "Costs",
+			shape:   Shape{RowCount: 3, ColumnCount: 4},
+			options: Options{Costs: []int{1, 5, 5, 1, 2, 3, 3, 2, 1, 5, 5, 1}},
+		},
+		{
+			name:    "Forbidden and mandatory clicks",
+			shape:   Shape{RowCount: 4, ColumnCount: 4},
+			options: Options{ForbiddenClicks: utils.BitVector{0b0000_0000_0010_0001}, MandatoryClicks: utils.BitVector{0b1000_0100_0000_0000}},
+		},
+		{
+			name:    "Tie-breaking",
+			shape:   Shape{RowCount: 4, ColumnCount: 4},
+			options: Options{TieBreaking: CentreTieBreaking},
+		},
+	}
+
+	freeVariableFixer := NewFreeVariableFixer(NewBruteForceOptimizer())
+	boardSolver := NewBoardSolver(NewGaussianEliminator(), freeVariableFixer)
+	precomputedSolver := NewPrecomputedSolver(freeVariableFixer)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			for board := uint64(0); board < 1<<testCase.shape.CellCount(); board++ {
+				// Act
+				expectedSolvability, expectedSolution, _ := boardSolver.SolveBoard(context.Background(), testCase.shape, utils.BitVector{board}, testCase.options)
+				solvability, solution, _ := precomputedSolver.SolveBoard(context.Background(), testCase.shape, utils.BitVector{board}, testCase.options)
+
+				// Assert
+				if solvability != expectedSolvability || !reflect.DeepEqual(solution, expectedSolution) {
+					t.Fatalf("Incorrect result for board %b: expected %v %v, got %v %v", board, expectedSolvability, expectedSolution, solvability, solution)
+				}
+			}
+		})
+	}
+}
+
+func TestPrecomputedSolverOnDefaultShape(t *testing.T) {
+	// Arrange
+	freeVariableFixer := NewFreeVariableFixer(NewBruteForceOptimizer())
+	boardSolver := NewBoardSolver(NewGaussianEliminator(), freeVariableFixer)
+	precomputedSolver := NewPrecomputedSolver(freeVariableFixer)
+
+	// A sample of the boards, as checking all of them would take too long
+	for board := uint64(0); board < 1<<DefaultShape.CellCount(); board += 7919 {
+		// Act
+		expectedSolvability, expectedSolution, _ := boardSolver.SolveBoard(context.Background(), DefaultShape, utils.BitVector{board}, Options{})
+		solvability, solution, _ := precomputedSolver.SolveBoard(context.Background(), DefaultShape, utils.BitVector{board}, Options{})
+
+		// Assert
+		if solvability != expectedSolvability || !reflect.DeepEqual(solution, expectedSolution) {
+			t.Fatalf("Incorrect result for board %b: expected %v %v, got %v %v", board, expectedSolvability, expectedSolution, solvability, solution)
+		}
+	}
+}
+
+func TestPrecomputedSolverCache(t *testing.T) {
+	// Arrange
+	solver := NewPrecomputedSolver(NewFreeVariableFixer(NewZeroValueOptimizer())).(*precomputedSolver)
+	board := utils.BitVector{0b000_000_001}
+
+	// Act
+	solver.SolveBoard(context.Background(), Shape{RowCount: 3, ColumnCount: 3}, board, Options{})
+	solver.SolveBoard(context.Background(), Shape{RowCount: 3, ColumnCount: 3}, board, Options{Target: utils.BitVector{0b000_000_011}})
+	solver.SolveBoard(context.Background(), Shape{RowCount: 3, ColumnCount: 3}, board, Options{TargetMask: utils.BitVector{0b111_111_110}})
+	solver.SolveBoard(context.Background(), Shape{RowCount: 3, ColumnCount: 3, Topology: ToroidalTopology}, board, Options{})
+
+	// Assert
+	// The target does not change the coefficients, so only the other three need their own factorization
+	if len(solver.factorizations) != 3 {
+		t.Errorf("Incorrect number of factorizations: expected 3, got %v", len(solver.factorizations))
+	}
+}
+
+func BenchmarkPrecomputedSolveBoardWithoutOptimizer(b *testing.B) {
+	optimizer := NewZeroValueOptimizer()
+	benchmarkSolveBoard(b, NewPrecomputedSolver(NewFreeVariableFixer(optimizer)))
+}
+
+func BenchmarkPrecomputedSolveBoardWithOptimizer(b *testing.B) {
+	optimizer := NewBruteForceOptimizer()
+	benchmarkSolveBoard(b, NewPrecomputedSolver(NewFreeVariableFixer(optimizer)))
+}
+
+// Compares the solvers on a larger board, where the elimination takes most of the time
+func BenchmarkSolveLargeBoard(b *testing.B) {
+	shape := Shape{RowCount: 16, ColumnCount: 16}
+	board := utils.NewBitVector(shape.CellCount())
+	for i := 0; i < shape.CellCount(); i += 3 {
+		board.SetBit(i)
+	}
+
+	freeVariableFixer := NewFreeVariableFixer(NewZeroValueOptimizer())
+	solvers := []struct {
+		name   string
+		solver BoardSolver
+	}{
+		{
+			name:   "Board solver",
+			solver: NewBoardSolver(NewGaussianEliminator(), freeVariableFixer),
+		},
+		{
+			name:   "Precomputed solver",
+			solver: NewPrecomputedSolver(freeVariableFixer),
+		},
+	}
+
+	for _, solver := range solvers {
+		b.Run(solver.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				solver.solver.SolveBoard(context.Background(), shape, board, Options{})
+			}
+		})
+	}
+}
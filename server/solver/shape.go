@@ -0,0 +1,190 @@
+package solver
+
+import (
+	"errors"
+	"fmt"
+	"server/utils"
+)
+
+// The upper limit on the cells of a board, to keep the time needed for the elimination reasonable
+const MaxCellCount = 1024
+
+type Topology int
+
+const (
+	// The cells on the edges of the board only have neighbours inside the board
+	PlanarTopology Topology = iota
+	// The left edge of the board wraps around to the right edge, and the top edge to the bottom edge
+	ToroidalTopology
+)
+
+type Grid int
+
+const (
+	// The cells are squares, arranged in rows and columns
+	SquareGrid Grid = iota
+	// The cells are hexagons, arranged in rows where every odd row is shifted half a cell to the right
+	HexagonalGrid
+)
+
+type Shape struct {
+	RowCount    int
+	ColumnCount int
+	Topology    Topology
+	Grid        Grid
+	// The cells toggled by a click, relative to the clicked cell, the plus shape is used if empty
+	Neighbourhood []Offset
+	// The cells toggled by a click on each cell, overriding the neighbourhood if not empty
+	FlipVectors []utils.BitVector
+	// The cells that exist on the board, every cell of the rectangle exists if empty
+	Mask utils.BitVector
+}
+
+var DefaultShape = Shape{RowCount: 5, ColumnCount: 5}
+
+func (s Shape) CellCount() int {
+	return s.RowCount * s.ColumnCount
+}
+
+func (s Shape) Validate() error {
+	if s.RowCount <= 0 || s.ColumnCount <= 0 {
+		return errors.New("the board must have at least one row and column")
+	}
+
+	if s.RowCount > MaxCellCount || s.ColumnCount > MaxCellCount || s.CellCount() > MaxCellCount {
+		return fmt.Errorf("the board can have at most %v cells", MaxCellCount)
+	}
+
+	if s.Topology != PlanarTopology && s.Topology != ToroidalTopology {
+		return fmt.Errorf("unknown topology %v", s.Topology)
+	}
+
+	if err := s.validateGrid(); err != nil {
+		return err
+	}
+
+	if len(s.Neighbourhood) > MaxCellCount {
+		return fmt.Errorf("the neighbourhood can have at most %v cells", MaxCellCount)
+	}
+
+	if len(s.Mask) > 0 {
+		if err := s.validateMask(); err != nil {
+			return err
+		}
+	}
+
+	if len(s.FlipVectors) > 0 {
+		return s.validateFlipVectors()
+	}
+
+	return nil
+}
+
+func (s Shape) validateGrid() error {
+	if s.Grid == SquareGrid {
+		return nil
+	}
+
+	if s.Grid != HexagonalGrid {
+		return fmt.Errorf("unknown grid %v", s.Grid)
+	}
+
+	if len(s.Neighbourhood) > 0 {
+		return errors.New("the neighbourhood cannot be given on a hexagonal grid")
+	}
+
+	// The shifted rows only line up when wrapping around if their number is even
+	if s.Topology == ToroidalTopology && s.RowCount%2 != 0 {
+		return errors.New("a toroidal hexagonal grid must have an even number of rows")
+	}
+
+	return nil
+}
+
+func (s Shape) validateMask() error {
+	cellCount := s.CellCount()
+	for j := cellCount; j < len(s.Mask)*64; j++ {
+		if s.Mask.TestBit(j) {
+			return errors.New("the mask contains cells outside the board")
+		}
+	}
+
+	if s.Mask.IsZero() {
+		return errors.New("the mask must contain at least one cell")
+	}
+
+	return nil
+}
+
+func (s Shape) validateFlipVectors() error {
+	if len(s.Neighbourhood) > 0 {
+		return errors.New("the neighbourhood cannot be given together with the flip vectors")
+	}
+
+	cellCount := s.CellCount()
+	if len(s.FlipVectors) != cellCount {
+		return fmt.Errorf("there has to be a flip vector for each of the %v cells", cellCount)
+	}
+
+	for i, flipVector := range s.FlipVectors {
+		for j := cellCount; j < len(flipVector)*64; j++ {
+			if flipVector.TestBit(j) {
+				return fmt.Errorf("the flip vector of cell %v contains cells outside the board", i)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Returns the indexes of the cells that exist on the board
+func (s Shape) Cells() []int {
+	cellSet := s.cellSet()
+
+	cells := make([]int, 0, cellSet.OnesCount())
+	for i := 0; i < s.CellCount(); i++ {
+		if cellSet.TestBit(i) {
+			cells = append(cells, i)
+		}
+	}
+
+	return cells
+}
+
+func (s Shape) HasCell(index int) bool {
+	return s.cellSet().TestBit(index)
+}
+
+// Returns the bit vector of the cells that exist on the board
+func (s Shape) cellSet() utils.BitVector {
+	cellCount := s.CellCount()
+	cellSet := utils.NewBitVector(cellCount)
+
+	if len(s.Mask) > 0 {
+		copy(cellSet, s.Mask)
+		return cellSet
+	}
+
+	for i := 0; i < cellCount; i++ {
+		cellSet.SetBit(i)
+	}
+
+	return cellSet
+}
+
+// Returns the offsets of the cells toggled by a click on a cell of the given row
+func (s Shape) neighbourhood(row int) []Offset {
+	if s.Grid == HexagonalGrid {
+		if row%2 == 0 {
+			return evenRowHexagonalOffsets
+		}
+
+		return oddRowHexagonalOffsets
+	}
+
+	if len(s.Neighbourhood) == 0 {
+		return vonNeumannOffsets
+	}
+
+	return s.Neighbourhood
+}
@@ -0,0 +1,190 @@
+package solver
+
+import (
+	"reflect"
+	"server/utils"
+	"testing"
+)
+
+func TestShapeValidation(t *testing.T) {
+	testCases := []struct {
+		name          string
+		shape         Shape
+		expectedValid bool
+	}{
+		{
+			name:          "Default shape",
+			shape:         DefaultShape,
+			expectedValid: true,
+		},
+		{
+			name:          "Single cell",
+			shape:         Shape{RowCount: 1, ColumnCount: 1},
+			expectedValid: true,
+		},
+		{
+			name:          "Largest possible shape",
+			shape:         Shape{RowCount: 32, ColumnCount: 32},
+			expectedValid: true,
+		},
+		{
+			name:          "Toroidal shape",
+			shape:         Shape{RowCount: 4, ColumnCount: 7, Topology: ToroidalTopology},
+			expectedValid: true,
+		},
+		{
+			name:          "Unknown topology",
+			shape:         Shape{RowCount: 4, ColumnCount: 7, Topology: Topology(42)},
+			expectedValid: false,
+		},
+		{
+			name:          "Custom neighbourhood",
+			shape:         Shape{RowCount: 4, ColumnCount: 7, Neighbourhood: []Offset{{0, 0}, {5, -3}}},
+			expectedValid: true,
+		},
+		{
+			name:          "Too large neighbourhood",
+			shape:         Shape{RowCount: 4, ColumnCount: 7, Neighbourhood: make([]Offset, MaxCellCount+1)},
+			expectedValid: false,
+		},
+		{
+			name:          "Explicit flip vectors",
+			shape:         Shape{RowCount: 1, ColumnCount: 2, FlipVectors: []utils.BitVector{{0b01}, {0b11, 0b0}}},
+			expectedValid: true,
+		},
+		{
+			name:          "Missing flip vector",
+			shape:         Shape{RowCount: 1, ColumnCount: 2, FlipVectors: []utils.BitVector{{0b01}}},
+			expectedValid: false,
+		},
+		{
+			name:          "Flip vector with cells outside the board",
+			shape:         Shape{RowCount: 1, ColumnCount: 2, FlipVectors: []utils.BitVector{{0b01}, {0b111}}},
+			expectedValid: false,
+		},
+		{
+			name:          "Flip vector with cells outside the board in the next word",
+			shape:         Shape{RowCount: 1, ColumnCount: 2, FlipVectors: []utils.BitVector{{0b01}, {0b11, 0b1}}},
+			expectedValid: false,
+		},
+		{
+			name:          "Both neighbourhood and flip vectors",
+			shape:         Shape{RowCount: 1, ColumnCount: 2, Neighbourhood: []Offset{{0, 0}}, FlipVectors: []utils.BitVector{{0b01}, {0b10}}},
+			expectedValid: false,
+		},
+		{
+			name:          "Hexagonal grid",
+			shape:         Shape{RowCount: 5, ColumnCount: 5, Grid: HexagonalGrid},
+			expectedValid: true,
+		},
+		{
+			name:          "Toroidal hexagonal grid",
+			shape:         Shape{RowCount: 4, ColumnCount: 5, Topology: ToroidalTopology, Grid: HexagonalGrid},
+			expectedValid: true,
+		},
+		{
+			name:          "Toroidal hexagonal grid with odd number of rows",
+			shape:         Shape{RowCount: 5, ColumnCount: 4, Topology: ToroidalTopology, Grid: HexagonalGrid},
+			expectedValid: false,
+		},
+		{
+			name:          "Hexagonal grid with neighbourhood",
+			shape:         Shape{RowCount: 5, ColumnCount: 5, Grid: HexagonalGrid, Neighbourhood: vonNeumannOffsets},
+			expectedValid: false,
+		},
+		{
+			name:          "Unknown grid",
+			shape:         Shape{RowCount: 5, ColumnCount: 5, Grid: Grid(42)},
+			expectedValid: false,
+		},
+		{
+			name:          "Masked shape",
+			shape:         Shape{RowCount: 3, ColumnCount: 3, Mask: utils.BitVector{0b010_111_010}},
+			expectedValid: true,
+		},
+		{
+			name:          "Mask with cells outside the board",
+			shape:         Shape{RowCount: 3, ColumnCount: 3, Mask: utils.BitVector{0b1_010_111_010}},
+			expectedValid: false,
+		},
+		{
+			name:          "Mask without cells",
+			shape:         Shape{RowCount: 3, ColumnCount: 3, Mask: utils.BitVector{0b0}},
+			expectedValid: false,
+		},
+		{
+			name:          "No rows",
+			shape:         Shape{RowCount: 0, ColumnCount: 5},
+			expectedValid: false,
+		},
+		{
+			name:          "No columns",
+			shape:         Shape{RowCount: 5, ColumnCount: 0},
+			expectedValid: false,
+		},
+		{
+			name:          "Too many cells",
+			shape:         Shape{RowCount: 33, ColumnCount: 32},
+			expectedValid: false,
+		},
+		{
+			name:          "Too many cells with overflowing cell count",
+			shape:         Shape{RowCount: 1 << 62, ColumnCount: 4},
+			expectedValid: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Act
+			err := testCase.shape.Validate()
+
+			// Assert
+			if (err == nil) != testCase.expectedValid {
+				t.Errorf("Incorrect result: expected valid: %v, got error: %v", testCase.expectedValid, err)
+			}
+		})
+	}
+}
+
+func TestShapeCells(t *testing.T) {
+	testCases := []struct {
+		name          string
+		shape         Shape
+		expectedCells []int
+	}{
+		{
+			name:          "Full board",
+			shape:         Shape{RowCount: 2, ColumnCount: 2},
+			expectedCells: []int{0, 1, 2, 3},
+		},
+		{
+			name:          "Masked board",
+			shape:         Shape{RowCount: 3, ColumnCount: 3, Mask: utils.BitVector{0b010_111_010}},
+			expectedCells: []int{1, 3, 4, 5, 7},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Act
+			cells := testCase.shape.Cells()
+
+			// Assert
+			if !reflect.DeepEqual(testCase.expectedCells, cells) {
+				t.Errorf("Incorrect result: expected %v, got %v", testCase.expectedCells, cells)
+			}
+
+			for i := 0; i < testCase.shape.CellCount(); i++ {
+				expectedHasCell := false
+				for _, cell := range testCase.expectedCells {
+					expectedHasCell = expectedHasCell || cell == i
+				}
+
+				if hasCell := testCase.shape.HasCell(i); hasCell != expectedHasCell {
+					t.Errorf("Incorrect result for cell %v: expected %v, got %v", i, expectedHasCell, hasCell)
+				}
+			}
+		})
+	}
+}
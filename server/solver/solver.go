@@ -1,12 +1,38 @@
 package solver
 
 import (
-	"math/bits"
+	"context"
+	"fmt"
 	"server/utils"
 )
 
+// Solvability tells whether a board has a solution, and if not, why
+type Solvability int
+
+const (
+	Solvable Solvability = iota
+	Unsolvable
+	// The board would have a solution, but not with the forbidden and mandatory clicks
+	UnsolvableUnderConstraints
+)
+
+func (s Solvability) String() string {
+	switch s {
+	case Solvable:
+		return "solvable"
+	case Unsolvable:
+		return "unsolvable"
+	case UnsolvableUnderConstraints:
+		return "unsolvable under constraints"
+	default:
+		return fmt.Sprintf("Solvability(%d)", int(s))
+	}
+}
+
 type BoardSolver interface {
-	SolveBoard(board uint32) (bool, uint32)
+	// Returns the solution with the fewest clicks, or once the context is done, the best one found so far.
+	// The last result tells whether the solution is proven optimal.
+	SolveBoard(ctx context.Context, shape Shape, board utils.BitVector, options Options) (Solvability, utils.BitVector, bool)
 }
 
 type boardSolver struct {
@@ -18,73 +44,197 @@ func NewBoardSolver(gaussianEliminator GaussianEliminator, freeVariableFixer Fre
 	return &boardSolver{gaussianEliminator: gaussianEliminator, freeVariableFixer: freeVariableFixer}
 }
 
-func (s *boardSolver) SolveBoard(board uint32) (bool, uint32) {
+func (s *boardSolver) SolveBoard(ctx context.Context, shape Shape, board utils.BitVector, options Options) (Solvability, utils.BitVector, bool) {
 	// Create the initial augmented matrix
-	augmentedMatrix := getAugmentedMatrix(board)
+	augmentedMatrix := getAugmentedMatrix(shape, board, options)
 
 	// Run the gaussian elimination algorithm
-	solvable, finalRow := s.gaussianEliminator.gaussianEliminate(&augmentedMatrix)
+	solvable, finalRow := s.gaussianEliminator.gaussianEliminate(augmentedMatrix)
 	if !solvable {
-		return false, 0
+		return determineUnsolvability(s.gaussianEliminator, shape, board, options), nil, true
 	}
 
-	// Fix the free variables to minimize "clicks" needed in the solution
-	s.freeVariableFixer.fixFreeVariables(&augmentedMatrix, finalRow)
+	return solveEliminatedEquations(ctx, s.freeVariableFixer, shape, augmentedMatrix, finalRow, options)
+}
+
+// Finds the solution of the equations eliminated up to the final row, with the free variables chosen by the tie-breaking rule if there is one,
+// and by the free variable fixer otherwise
+func solveEliminatedEquations(ctx context.Context, freeVariableFixer FreeVariableFixer, shape Shape, augmentedMatrix []utils.BitVector, finalRow int, options Options) (Solvability, utils.BitVector, bool) {
+	// Choosing between the tied optimal solutions needs all of them, so the whole solution space is searched instead
+	if options.TieBreaking != NoTieBreaking {
+		space := getSolutionSpace(shape, augmentedMatrix, finalRow, options)
+		solution, optimal := space.OptimalSolution(ctx, shape, options)
+		return Solvable, solution, optimal
+	}
+
+	// Fix the free variables to minimize the cost of the "clicks" needed in the solution
+	optimal := freeVariableFixer.fixFreeVariables(ctx, augmentedMatrix, finalRow, options.variableConstraints(shape))
 
 	// Determine the solution from the final matrix
-	solution := determineSolution(&augmentedMatrix)
-	return true, solution
+	solution := determineSolution(augmentedMatrix)
+	return Solvable, expandSolution(shape, solution), optimal
+}
+
+// Checks whether the board could be solved at all without the forbidden and mandatory clicks
+func determineUnsolvability(gaussianEliminator GaussianEliminator, shape Shape, board utils.BitVector, options Options) Solvability {
+	return determineUnsolvabilityWith(options, func(options Options) bool {
+		solvable, _ := gaussianEliminator.gaussianEliminate(getAugmentedMatrix(shape, board, options))
+		return solvable
+	})
 }
 
-func getAugmentedMatrix(board uint32) (matrix [MatrixSize]uint32) {
-	for i := uint8(0); i < MatrixSize; i++ {
-		flipVector := getFlipVector(i)
-		if utils.TestBit(board, i) {
-			flipVector = utils.SetBit(flipVector, constantRow)
+// Checks whether the board could be solved at all without the forbidden and mandatory clicks, using the given check of the solvability with other options
+func determineUnsolvabilityWith(options Options, isSolvable func(options Options) bool) Solvability {
+	if !options.hasClickConstraints() {
+		return Unsolvable
+	}
+
+	options.ForbiddenClicks = nil
+	options.MandatoryClicks = nil
+	if isSolvable(options) {
+		return UnsolvableUnderConstraints
+	}
+
+	return Unsolvable
+}
+
+// Creates the augmented matrix of the equations, with a variable and an equation for each existing cell of the board
+func getAugmentedMatrix(shape Shape, board utils.BitVector, options Options) []utils.BitVector {
+	cells := shape.Cells()
+	matrixSize := len(cells)
+	constantRow := matrixSize
+
+	// The clicks have to toggle the cells that differ from the target
+	toggledCells := options.toggledCells(shape, board)
+
+	matrix := make([]utils.BitVector, matrixSize)
+	for i, cell := range cells {
+		matrix[i] = utils.NewBitVector(matrixSize + 1)
+		if toggledCells.TestBit(cell) {
+			matrix[i].SetBit(constantRow)
+		}
+	}
+
+	// The flip vector of a cell is the column of its variable, as the neighbourhood does not have to be symmetric
+	for j, cell := range cells {
+		flipVector := getFlipVector(shape, cell)
+		for i, otherCell := range cells {
+			if flipVector.TestBit(otherCell) {
+				matrix[i].SetBit(j)
+			}
+		}
+	}
+
+	// The equations of the cells that can end up in any state are dropped, leaving empty rows in their place,
+	// so the matrix stays square and the elimination finds the extra free variables
+	for i, cell := range cells {
+		if !options.hasTarget(cell) {
+			matrix[i] = utils.NewBitVector(matrixSize + 1)
+		}
+	}
+
+	// The variables of the forbidden and mandatory clicks are substituted with their fixed values,
+	// so the mandatory clicks are applied to the constants, and their columns are cleared
+	for j, cell := range cells {
+		if !options.isForbidden(cell) && !options.isMandatory(cell) {
+			continue
+		}
+
+		for i := range matrix {
+			if matrix[i].TestBit(j) {
+				if options.isMandatory(cell) {
+					matrix[i].FlipBit(constantRow)
+				}
+				matrix[i].ClearBit(j)
+			}
 		}
+	}
+
+	return matrix
+}
+
+// Maps the solution of the existing cells back to the indexes of the whole board
+func expandSolution(shape Shape, solution utils.BitVector) utils.BitVector {
+	if len(shape.Mask) == 0 {
+		return solution
+	}
 
-		matrix[i] = flipVector
+	expandedSolution := utils.NewBitVector(shape.CellCount())
+	for i, cell := range shape.Cells() {
+		if solution.TestBit(i) {
+			expandedSolution.SetBit(cell)
+		}
 	}
 
-	return
+	return expandedSolution
 }
 
-func getFlipVector(index uint8) (flipVector uint32) {
-	// The current position
-	flipVector = utils.SetBit(flipVector, index)
+func getFlipVector(shape Shape, index int) utils.BitVector {
+	flipVector := utils.NewBitVector(shape.CellCount())
 
-	// North
-	if index > ColumnCount-1 {
-		flipVector = utils.SetBit(flipVector, index-ColumnCount)
+	// The explicitly given flip vectors can have any capacity, only the bits of the cells are copied
+	if len(shape.FlipVectors) > 0 {
+		copy(flipVector, shape.FlipVectors[index])
+		return maskFlipVector(shape, index, flipVector)
 	}
 
-	// South
-	if index < (RowCount-1)*ColumnCount {
-		flipVector = utils.SetBit(flipVector, index+ColumnCount)
+	rowCount := shape.RowCount
+	columnCount := shape.ColumnCount
+	row := index / columnCount
+	column := index % columnCount
+
+	// A cell is only flipped once, even if it is reached by multiple offsets
+	for _, offset := range shape.neighbourhood(row) {
+		targetRow := row + offset.Row
+		targetColumn := column + offset.Column
+
+		// Cells that would fall off the board wrap around on a torus
+		if shape.Topology == ToroidalTopology {
+			targetRow = modulo(targetRow, rowCount)
+			targetColumn = modulo(targetColumn, columnCount)
+		}
+
+		if targetRow < 0 || targetRow >= rowCount || targetColumn < 0 || targetColumn >= columnCount {
+			continue
+		}
+
+		flipVector.SetBit(targetRow*columnCount + targetColumn)
 	}
 
-	// West
-	if index%ColumnCount > 0 {
-		flipVector = utils.SetBit(flipVector, index-1)
+	return maskFlipVector(shape, index, flipVector)
+}
+
+// Removes the missing cells from the flip vector, as they cannot be toggled, nor clicked
+func maskFlipVector(shape Shape, index int, flipVector utils.BitVector) utils.BitVector {
+	if len(shape.Mask) == 0 {
+		return flipVector
 	}
 
-	// East
-	if index%ColumnCount < ColumnCount-1 {
-		flipVector = utils.SetBit(flipVector, index+1)
+	if !shape.HasCell(index) {
+		return utils.NewBitVector(shape.CellCount())
 	}
 
-	return
+	flipVector.And(shape.Mask)
+	return flipVector
 }
 
-func determineSolution(augmentedMatrix *[MatrixSize]uint32) (solution uint32) {
-	for i := uint8(0); i < MatrixSize; i++ {
-		pivotColumn := uint8(bits.TrailingZeros32(augmentedMatrix[i]))
+// Returns the non-negative remainder of the division
+func modulo(value, divisor int) int {
+	return ((value % divisor) + divisor) % divisor
+}
+
+func determineSolution(augmentedMatrix []utils.BitVector) utils.BitVector {
+	constantRow := len(augmentedMatrix)
+
+	solution := utils.NewBitVector(len(augmentedMatrix))
+	for _, row := range augmentedMatrix {
+		pivotColumn := row.TrailingZeros()
 
 		// Update the solution according to the current row
-		if utils.TestBit(augmentedMatrix[i], constantRow) {
-			solution = utils.SetBit(solution, pivotColumn)
+		if row.TestBit(constantRow) {
+			solution.SetBit(pivotColumn)
 		}
 	}
 
-	return
+	return solution
 }
@@ -1,24 +1,51 @@
 package solver
 
 import (
+	"context"
 	"reflect"
 	"server/utils"
 	"testing"
+	"time"
+)
+
+// The toggle patterns of Merlin's Magic Square: the corners toggle their 2x2 block,
+// the edges toggle their whole edge and the centre toggles the plus shape
+var merlinsMagicSquare = Shape{
+	RowCount:    3,
+	ColumnCount: 3,
+	FlipVectors: []utils.BitVector{
+		{0b000_011_011},
+		{0b000_000_111},
+		{0b000_110_110},
+		{0b001_001_001},
+		{0b010_111_010},
+		{0b100_100_100},
+		{0b011_011_000},
+		{0b111_000_000},
+		{0b110_110_000},
+	},
+}
+
+// Irregular boards, with the missing cells masked out
+var (
+	crossBoard   = Shape{RowCount: 3, ColumnCount: 3, Mask: utils.BitVector{0b010_111_010}}
+	lShapedBoard = Shape{RowCount: 3, ColumnCount: 3, Mask: utils.BitVector{0b111_001_001}}
+	diamondBoard = Shape{RowCount: 5, ColumnCount: 5, Mask: utils.BitVector{0b00100_01110_11111_01110_00100}}
 )
 
 // Mock implementation of the gaussian eliminator interface
 type mockGaussianEliminator struct {
 	t         *testing.T
 	allowCall bool
-	board     uint32
+	board     utils.BitVector
 	solvable  bool
-	finalRow  uint8
-	result    *[MatrixSize]uint32
+	finalRow  int
+	result    []utils.BitVector
 
 	wasCalled bool
 }
 
-func (m *mockGaussianEliminator) gaussianEliminate(augmentedMatrix *[MatrixSize]uint32) (bool, uint8) {
+func (m *mockGaussianEliminator) gaussianEliminate(augmentedMatrix []utils.BitVector) (bool, int) {
 	// Save that the mock was called
 	m.wasCalled = true
 
@@ -29,117 +56,128 @@ func (m *mockGaussianEliminator) gaussianEliminate(augmentedMatrix *[MatrixSize]
 	}
 
 	// Set up the expected coefficient matrix
-	expectedCoefficients := [MatrixSize]uint32{
-		0b00000_00000_00000_00001_00011,
-		0b00000_00000_00000_00010_00111,
-		0b00000_00000_00000_00100_01110,
-		0b00000_00000_00000_01000_11100,
-		0b00000_00000_00000_10000_11000,
-		0b00000_00000_00001_00011_00001,
-		0b00000_00000_00010_00111_00010,
-		0b00000_00000_00100_01110_00100,
-		0b00000_00000_01000_11100_01000,
-		0b00000_00000_10000_11000_10000,
-		0b00000_00001_00011_00001_00000,
-		0b00000_00010_00111_00010_00000,
-		0b00000_00100_01110_00100_00000,
-		0b00000_01000_11100_01000_00000,
-		0b00000_10000_11000_10000_00000,
-		0b00001_00011_00001_00000_00000,
-		0b00010_00111_00010_00000_00000,
-		0b00100_01110_00100_00000_00000,
-		0b01000_11100_01000_00000_00000,
-		0b10000_11000_10000_00000_00000,
-		0b00011_00001_00000_00000_00000,
-		0b00111_00010_00000_00000_00000,
-		0b01110_00100_00000_00000_00000,
-		0b11100_01000_00000_00000_00000,
-		0b11000_10000_00000_00000_00000,
+	expectedCoefficients := []utils.BitVector{
+		{0b00000_00000_00000_00001_00011},
+		{0b00000_00000_00000_00010_00111},
+		{0b00000_00000_00000_00100_01110},
+		{0b00000_00000_00000_01000_11100},
+		{0b00000_00000_00000_10000_11000},
+		{0b00000_00000_00001_00011_00001},
+		{0b00000_00000_00010_00111_00010},
+		{0b00000_00000_00100_01110_00100},
+		{0b00000_00000_01000_11100_01000},
+		{0b00000_00000_10000_11000_10000},
+		{0b00000_00001_00011_00001_00000},
+		{0b00000_00010_00111_00010_00000},
+		{0b00000_00100_01110_00100_00000},
+		{0b00000_01000_11100_01000_00000},
+		{0b00000_10000_11000_10000_00000},
+		{0b00001_00011_00001_00000_00000},
+		{0b00010_00111_00010_00000_00000},
+		{0b00100_01110_00100_00000_00000},
+		{0b01000_11100_01000_00000_00000},
+		{0b10000_11000_10000_00000_00000},
+		{0b00011_00001_00000_00000_00000},
+		{0b00111_00010_00000_00000_00000},
+		{0b01110_00100_00000_00000_00000},
+		{0b11100_01000_00000_00000_00000},
+		{0b11000_10000_00000_00000_00000},
 	}
 
 	// Verify the input is correct
-	for i := uint8(0); i < MatrixSize; i++ {
-		expectedRow := expectedCoefficients[i]
-		if utils.TestBit(m.board, i) {
-			expectedRow = utils.SetBit(expectedRow, MatrixSize)
+	if len(augmentedMatrix) != len(expectedCoefficients) {
+		m.t.Fatalf("Calling mock gaussian eliminator with incorrect input size: expected %v, got %v", len(expectedCoefficients), len(augmentedMatrix))
+	}
+
+	for i := range expectedCoefficients {
+		expectedRow := expectedCoefficients[i].Clone()
+		if m.board.TestBit(i) {
+			expectedRow.SetBit(len(expectedCoefficients))
 		}
 
-		if augmentedMatrix[i] != expectedRow {
+		if !augmentedMatrix[i].Equal(expectedRow) {
 			m.t.Fatalf("Calling mock gaussian eliminator with incorrect input: expected %v, got %v (at matrix line %v)", expectedRow, augmentedMatrix[i], i)
 		}
 	}
 
 	// Return the configured results
-	*augmentedMatrix = *m.result
+	copy(augmentedMatrix, m.result)
 	return m.solvable, m.finalRow
 }
 
 // Mock implementation of the free variable fixer interface
 type mockFreeVariableFixer struct {
-	t         *testing.T
-	allowCall bool
-	matrix    *[MatrixSize]uint32
-	finalRow  uint8
-	result    *[MatrixSize]uint32
+	t           *testing.T
+	allowCall   bool
+	matrix      []utils.BitVector
+	finalRow    int
+	constraints variableConstraints
+	result      []utils.BitVector
 
 	wasCalled bool
 }
 
-func (m *mockFreeVariableFixer) fixFreeVariables(augmentedMatrix *[MatrixSize]uint32, finalRow uint8) {
+func (m *mockFreeVariableFixer) fixFreeVariables(ctx context.Context, augmentedMatrix []utils.BitVector, finalRow int, constraints variableConstraints) bool {
 	// Save that the mock was called
 	m.wasCalled = true
 
 	// Check whether it's OK to call the mock
 	if !m.allowCall {
 		m.t.Fatalf("Mock free variable fixer should not be called")
-		return
+		return false
 	}
 
 	// Check whether we got the expected input
 	if finalRow != m.finalRow {
 		m.t.Fatalf("Calling mock free variable fixer with incorrect input (finalRow): expected %v, got %v", m.finalRow, finalRow)
-		return
+		return false
+	}
+
+	if !reflect.DeepEqual(m.constraints, constraints) {
+		m.t.Fatalf("Calling mock free variable fixer with incorrect input (constraints): expected %v, got %v", m.constraints, constraints)
+		return false
 	}
 
 	if !reflect.DeepEqual(m.matrix, augmentedMatrix) {
 		m.t.Fatal("Calling mock free variable fixer with incorrect input (augmentedMatrix)")
-		return
+		return false
 	}
 
 	// Set the configured result
-	*augmentedMatrix = *m.result
+	copy(augmentedMatrix, m.result)
+	return true
 }
 
 func TestNoSolution(t *testing.T) {
 	// Arrange
-	board := uint32(0b00101_00011_10001_01100_10011)
-
-	gaussianResult := [MatrixSize]uint32{
-		0b1_00000_00000_00000_00000_00000,
-		0b1_00000_00000_00000_00000_00000,
-		0b1_00000_00000_00000_00000_00000,
-		0b1_00000_00000_00000_00000_00000,
-		0b1_00000_00000_00000_00000_00000,
-		0b1_00000_00000_00000_00000_00000,
-		0b1_00000_00000_00000_00000_00000,
-		0b1_00000_00000_00000_00000_00000,
-		0b1_00000_00000_00000_00000_00000,
-		0b1_00000_00000_00000_00000_00000,
-		0b1_00000_00000_00000_00000_00000,
-		0b1_00000_00000_00000_00000_00000,
-		0b1_00000_00000_00000_00000_00000,
-		0b1_00000_00000_00000_00000_00000,
-		0b1_00000_00000_00000_00000_00000,
-		0b1_00000_00000_00000_00000_00000,
-		0b1_00000_00000_00000_00000_00000,
-		0b1_00000_00000_00000_00000_00000,
-		0b1_00000_00000_00000_00000_00000,
-		0b1_00000_00000_00000_00000_00000,
-		0b1_00000_00000_00000_00000_00000,
-		0b1_00000_00000_00000_00000_00000,
-		0b1_00000_00000_00000_00000_00000,
-		0b1_00000_00000_00000_00000_00000,
-		0b1_00000_00000_00000_00000_00000,
+	board := utils.BitVector{0b00101_00011_10001_01100_10011}
+
+	gaussianResult := []utils.BitVector{
+		{0b1_00000_00000_00000_00000_00000},
+		{0b1_00000_00000_00000_00000_00000},
+		{0b1_00000_00000_00000_00000_00000},
+		{0b1_00000_00000_00000_00000_00000},
+		{0b1_00000_00000_00000_00000_00000},
+		{0b1_00000_00000_00000_00000_00000},
+		{0b1_00000_00000_00000_00000_00000},
+		{0b1_00000_00000_00000_00000_00000},
+		{0b1_00000_00000_00000_00000_00000},
+		{0b1_00000_00000_00000_00000_00000},
+		{0b1_00000_00000_00000_00000_00000},
+		{0b1_00000_00000_00000_00000_00000},
+		{0b1_00000_00000_00000_00000_00000},
+		{0b1_00000_00000_00000_00000_00000},
+		{0b1_00000_00000_00000_00000_00000},
+		{0b1_00000_00000_00000_00000_00000},
+		{0b1_00000_00000_00000_00000_00000},
+		{0b1_00000_00000_00000_00000_00000},
+		{0b1_00000_00000_00000_00000_00000},
+		{0b1_00000_00000_00000_00000_00000},
+		{0b1_00000_00000_00000_00000_00000},
+		{0b1_00000_00000_00000_00000_00000},
+		{0b1_00000_00000_00000_00000_00000},
+		{0b1_00000_00000_00000_00000_00000},
+		{0b1_00000_00000_00000_00000_00000},
 	}
 	gaussianEliminator := &mockGaussianEliminator{
 		t:         t,
@@ -147,7 +185,7 @@ func TestNoSolution(t *testing.T) {
 		board:     board,
 		solvable:  false,
 		finalRow:  0,
-		result:    &gaussianResult,
+		result:    gaussianResult,
 	}
 
 	freeVariableFixer := &mockFreeVariableFixer{
@@ -161,11 +199,11 @@ func TestNoSolution(t *testing.T) {
 	solver := NewBoardSolver(gaussianEliminator, freeVariableFixer)
 
 	// Act
-	solvable, _ := solver.SolveBoard(board)
+	solvability, _, _ := solver.SolveBoard(context.Background(), DefaultShape, board, Options{})
 
 	// Assert
-	if solvable {
-		t.Error("Incorrect result: expected not solvable, got solvable")
+	if solvability != Unsolvable {
+		t.Errorf("Incorrect result: expected %v, got %v", Unsolvable, solvability)
 	}
 
 	if !gaussianEliminator.wasCalled {
@@ -179,34 +217,34 @@ func TestNoSolution(t *testing.T) {
 
 func TestHasSolution(t *testing.T) {
 	// Arrange
-	board := uint32(0b00101_00011_10001_01100_10011)
-
-	gaussianResult := [MatrixSize]uint32{
-		0b0_00000_00000_00000_00000_00001,
-		0b0_00000_00000_00000_00000_00010,
-		0b0_00000_00000_00000_00000_00100,
-		0b0_00000_00000_00000_00000_01000,
-		0b0_00000_00000_00000_00000_10000,
-		0b0_00000_00000_00000_00001_00000,
-		0b0_00000_00000_00000_00010_00000,
-		0b0_00000_00000_00000_00100_00000,
-		0b0_00000_00000_00000_01000_00000,
-		0b0_00000_00000_00000_10000_00000,
-		0b0_00000_00000_00000_00000_00000,
-		0b0_00000_00000_00000_00000_00000,
-		0b0_00000_00000_00000_00000_00000,
-		0b0_00000_00000_00000_00000_00000,
-		0b0_00000_00000_00000_00000_00000,
-		0b0_00000_00000_00000_00000_00000,
-		0b0_00000_00000_00000_00000_00000,
-		0b0_00000_00000_00000_00000_00000,
-		0b0_00000_00000_00000_00000_00000,
-		0b0_00000_00000_00000_00000_00000,
-		0b0_00000_00000_00000_00000_00000,
-		0b0_00000_00000_00000_00000_00000,
-		0b0_00000_00000_00000_00000_00000,
-		0b0_00000_00000_00000_00000_00000,
-		0b0_00000_00000_00000_00000_00000,
+	board := utils.BitVector{0b00101_00011_10001_01100_10011}
+
+	gaussianResult := []utils.BitVector{
+		{0b0_00000_00000_00000_00000_00001},
+		{0b0_00000_00000_00000_00000_00010},
+		{0b0_00000_00000_00000_00000_00100},
+		{0b0_00000_00000_00000_00000_01000},
+		{0b0_00000_00000_00000_00000_10000},
+		{0b0_00000_00000_00000_00001_00000},
+		{0b0_00000_00000_00000_00010_00000},
+		{0b0_00000_00000_00000_00100_00000},
+		{0b0_00000_00000_00000_01000_00000},
+		{0b0_00000_00000_00000_10000_00000},
+		{0b0_00000_00000_00000_00000_00000},
+		{0b0_00000_00000_00000_00000_00000},
+		{0b0_00000_00000_00000_00000_00000},
+		{0b0_00000_00000_00000_00000_00000},
+		{0b0_00000_00000_00000_00000_00000},
+		{0b0_00000_00000_00000_00000_00000},
+		{0b0_00000_00000_00000_00000_00000},
+		{0b0_00000_00000_00000_00000_00000},
+		{0b0_00000_00000_00000_00000_00000},
+		{0b0_00000_00000_00000_00000_00000},
+		{0b0_00000_00000_00000_00000_00000},
+		{0b0_00000_00000_00000_00000_00000},
+		{0b0_00000_00000_00000_00000_00000},
+		{0b0_00000_00000_00000_00000_00000},
+		{0b0_00000_00000_00000_00000_00000},
 	}
 	gaussianEliminator := &mockGaussianEliminator{
 		t:         t,
@@ -214,56 +252,56 @@ func TestHasSolution(t *testing.T) {
 		board:     board,
 		solvable:  true,
 		finalRow:  10,
-		result:    &gaussianResult,
-	}
-
-	fixedFreeVariablesResult := [MatrixSize]uint32{
-		0b0_00000_00000_00000_00000_00001,
-		0b0_00000_00000_00000_00000_00010,
-		0b1_00000_00000_00000_00000_00100,
-		0b1_00000_00000_00000_00000_01000,
-		0b0_00000_00000_00000_00000_10000,
-		0b0_00000_00000_00000_00001_00000,
-		0b1_00000_00000_00000_00010_00000,
-		0b0_00000_00000_00000_00100_00000,
-		0b1_00000_00000_00000_01000_00000,
-		0b1_00000_00000_00000_10000_00000,
-		0b1_10000_00000_00000_00000_00000,
-		0b1_01000_00000_00000_00000_00000,
-		0b0_00100_00000_00000_00000_00000,
-		0b0_00010_00000_00000_00000_00000,
-		0b0_00001_00000_00000_00000_00000,
-		0b0_00000_10000_00000_00000_00000,
-		0b0_00000_01000_00000_00000_00000,
-		0b1_00000_00100_00000_00000_00000,
-		0b1_00000_00010_00000_00000_00000,
-		0b0_00000_00001_00000_00000_00000,
-		0b0_00000_00000_10000_00000_00000,
-		0b1_00000_00000_01000_00000_00000,
-		0b0_00000_00000_00100_00000_00000,
-		0b0_00000_00000_00010_00000_00000,
-		0b0_00000_00000_00001_00000_00000,
+		result:    gaussianResult,
+	}
+
+	fixedFreeVariablesResult := []utils.BitVector{
+		{0b0_00000_00000_00000_00000_00001},
+		{0b0_00000_00000_00000_00000_00010},
+		{0b1_00000_00000_00000_00000_00100},
+		{0b1_00000_00000_00000_00000_01000},
+		{0b0_00000_00000_00000_00000_10000},
+		{0b0_00000_00000_00000_00001_00000},
+		{0b1_00000_00000_00000_00010_00000},
+		{0b0_00000_00000_00000_00100_00000},
+		{0b1_00000_00000_00000_01000_00000},
+		{0b1_00000_00000_00000_10000_00000},
+		{0b1_10000_00000_00000_00000_00000},
+		{0b1_01000_00000_00000_00000_00000},
+		{0b0_00100_00000_00000_00000_00000},
+		{0b0_00010_00000_00000_00000_00000},
+		{0b0_00001_00000_00000_00000_00000},
+		{0b0_00000_10000_00000_00000_00000},
+		{0b0_00000_01000_00000_00000_00000},
+		{0b1_00000_00100_00000_00000_00000},
+		{0b1_00000_00010_00000_00000_00000},
+		{0b0_00000_00001_00000_00000_00000},
+		{0b0_00000_00000_10000_00000_00000},
+		{0b1_00000_00000_01000_00000_00000},
+		{0b0_00000_00000_00100_00000_00000},
+		{0b0_00000_00000_00010_00000_00000},
+		{0b0_00000_00000_00001_00000_00000},
 	}
 	freeVariableFixer := &mockFreeVariableFixer{
 		t:         t,
 		allowCall: true,
-		matrix:    &gaussianResult,
+		matrix:    gaussianResult,
 		finalRow:  10,
-		result:    &fixedFreeVariablesResult,
+		result:    fixedFreeVariablesResult,
 	}
 
 	solver := NewBoardSolver(gaussianEliminator, freeVariableFixer)
 
 	// Act
-	solvable, solution := solver.SolveBoard(board)
+	solvability, solution, _ := solver.SolveBoard(context.Background(), DefaultShape, board, Options{})
 
 	// Assert
-	if !solvable {
-		t.Error("Incorrect result: expected solvable, got not solvable")
+	if solvability != Solvable {
+		t.Errorf("Incorrect result: expected %v, got %v", Solvable, solvability)
 	}
 
-	expectedSolution := uint32(0b_11000_00110_01000_11010_01100)
-	if solution != expectedSolution {
+	expectedSolution := utils.BitVector{0b_11000_00110_01000_11010_01100}
+	if !solution.Equal(expectedSolution) {
 		t.Errorf("Incorrect result for solution: expected %v, got %v", expectedSolution, solution)
 	}
 
@@ -276,29 +314,849 @@ func TestHasSolution(t *testing.T) {
 	}
 }
 
-func benchmarkSolveBoard(b *testing.B, optimizer Optimizer) {
+func TestGetAugmentedMatrix(t *testing.T) {
+	testCases := []struct {
+		name           string
+		shape          Shape
+		board          utils.BitVector
+		options        Options
+		expectedMatrix []utils.BitVector
+	}{
+		{
+			name:  "3x3 board",
+			shape: Shape{RowCount: 3, ColumnCount: 3},
+			board: utils.BitVector{0b100_010_001},
+			expectedMatrix: []utils.BitVector{
+				{0b1_000_001_011},
+				{0b0_000_010_111},
+				{0b0_000_100_110},
+				{0b0_001_011_001},
+				{0b1_010_111_010},
+				{0b0_100_110_100},
+				{0b0_011_001_000},
+				{0b0_111_010_000},
+				{0b1_110_100_000},
+			},
+		},
+		{
+			name:  "2x4 board",
+			shape: Shape{RowCount: 2, ColumnCount: 4},
+			board: utils.BitVector{0b1111_0000},
+			expectedMatrix: []utils.BitVector{
+				{0b0_0001_0011},
+				{0b0_0010_0111},
+				{0b0_0100_1110},
+				{0b0_1000_1100},
+				{0b1_0011_0001},
+				{0b1_0111_0010},
+				{0b1_1110_0100},
+				{0b1_1100_1000},
+			},
+		},
+		{
+			name:  "3x3 toroidal board",
+			shape: Shape{RowCount: 3, ColumnCount: 3, Topology: ToroidalTopology},
+			board: utils.BitVector{0b000_000_001},
+			expectedMatrix: []utils.BitVector{
+				{0b1_001_001_111},
+				{0b0_010_010_111},
+				{0b0_100_100_111},
+				{0b0_001_111_001},
+				{0b0_010_111_010},
+				{0b0_100_111_100},
+				{0b0_111_001_001},
+				{0b0_111_010_010},
+				{0b0_111_100_100},
+			},
+		},
+		{
+			name:  "4x2 toroidal board",
+			shape: Shape{RowCount: 4, ColumnCount: 2, Topology: ToroidalTopology},
+			board: utils.BitVector{0b00_00_00_00},
+			expectedMatrix: []utils.BitVector{
+				{0b0_01_00_01_11},
+				{0b0_10_00_10_11},
+				{0b0_00_01_11_01},
+				{0b0_00_10_11_10},
+				{0b0_01_11_01_00},
+				{0b0_10_11_10_00},
+				{0b0_11_01_00_01},
+				{0b0_11_10_00_10},
+			},
+		},
+		{
+			name:  "Asymmetric neighbourhood",
+			shape: Shape{RowCount: 1, ColumnCount: 3, Neighbourhood: []Offset{{0, 0}, {0, 1}}},
+			board: utils.BitVector{0b100},
+			expectedMatrix: []utils.BitVector{
+				{0b0_001},
+				{0b0_011},
+				{0b1_110},
+			},
+		},
+		{
+			name:  "Merlin's Magic Square",
+			shape: merlinsMagicSquare,
+			board: utils.BitVector{0b000_000_000},
+			expectedMatrix: []utils.BitVector{
+				{0b0_000_001_011},
+				{0b0_000_010_111},
+				{0b0_000_100_110},
+				{0b0_001_011_001},
+				{0b0_101_010_101},
+				{0b0_100_110_100},
+				{0b0_011_001_000},
+				{0b0_111_010_000},
+				{0b0_110_100_000},
+			},
+		},
+		{
+			name:  "Single row",
+			shape: Shape{RowCount: 1, ColumnCount: 3},
+			board: utils.BitVector{0b010},
+			expectedMatrix: []utils.BitVector{
+				{0b0_011},
+				{0b1_111},
+				{0b0_110},
+			},
+		},
+		{
+			name:    "Target board",
+			shape:   Shape{RowCount: 1, ColumnCount: 3},
+			board:   utils.BitVector{0b010},
+			options: Options{Target: utils.BitVector{0b011}},
+			expectedMatrix: []utils.BitVector{
+				{0b1_011},
+				{0b0_111},
+				{0b0_110},
+			},
+		},
+		{
+			name:    "Target mask",
+			shape:   Shape{RowCount: 1, ColumnCount: 3},
+			board:   utils.BitVector{0b111},
+			options: Options{TargetMask: utils.BitVector{0b101}},
+			expectedMatrix: []utils.BitVector{
+				{0b1_011},
+				{0b0_000},
+				{0b1_110},
+			},
+		},
+		{
+			name:  "Masked board",
+			shape: crossBoard,
+			board: utils.BitVector{0b000_010_000},
+			expectedMatrix: []utils.BitVector{
+				{0b0_00101},
+				{0b0_00110},
+				{0b1_11111},
+				{0b0_01100},
+				{0b0_10100},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Act
+			matrix := getAugmentedMatrix(testCase.shape, testCase.board, testCase.options)
+
+			// Assert
+			if !reflect.DeepEqual(testCase.expectedMatrix, matrix) {
+				t.Errorf("Incorrect result: expected %b, got %b", testCase.expectedMatrix, matrix)
+			}
+		})
+	}
+}
+
+func TestGetFlipVector(t *testing.T) {
+	testCases := []struct {
+		name           string
+		shape          Shape
+		index          int
+		expectedResult utils.BitVector
+	}{
+		{
+			name:           "Default neighbourhood",
+			shape:          Shape{RowCount: 3, ColumnCount: 3},
+			index:          4,
+			expectedResult: utils.BitVector{0b010_111_010},
+		},
+		{
+			name:           "Moore neighbourhood in the middle",
+			shape:          Shape{RowCount: 3, ColumnCount: 3, Neighbourhood: MooreNeighbourhood.Offsets(3, 3)},
+			index:          4,
+			expectedResult: utils.BitVector{0b111_111_111},
+		},
+		{
+			name:           "Moore neighbourhood in the corner",
+			shape:          Shape{RowCount: 3, ColumnCount: 3, Neighbourhood: MooreNeighbourhood.Offsets(3, 3)},
+			index:          0,
+			expectedResult: utils.BitVector{0b000_011_011},
+		},
+		{
+			name:           "Moore neighbourhood in the corner of a torus",
+			shape:          Shape{RowCount: 3, ColumnCount: 3, Topology: ToroidalTopology, Neighbourhood: MooreNeighbourhood.Offsets(3, 3)},
+			index:          0,
+			expectedResult: utils.BitVector{0b111_111_111},
+		},
+		{
+			name:           "X neighbourhood",
+			shape:          Shape{RowCount: 3, ColumnCount: 3, Neighbourhood: XNeighbourhood.Offsets(3, 3)},
+			index:          4,
+			expectedResult: utils.BitVector{0b101_010_101},
+		},
+		{
+			name:           "Knight neighbourhood",
+			shape:          Shape{RowCount: 3, ColumnCount: 3, Neighbourhood: KnightNeighbourhood.Offsets(3, 3)},
+			index:          0,
+			expectedResult: utils.BitVector{0b010_100_001},
+		},
+		{
+			name:           "Cross neighbourhood",
+			shape:          Shape{RowCount: 3, ColumnCount: 4, Neighbourhood: CrossNeighbourhood.Offsets(3, 4)},
+			index:          5,
+			expectedResult: utils.BitVector{0b0010_1111_0010},
+		},
+		{
+			name:           "Cross neighbourhood on a torus",
+			shape:          Shape{RowCount: 3, ColumnCount: 4, Topology: ToroidalTopology, Neighbourhood: CrossNeighbourhood.Offsets(3, 4)},
+			index:          5,
+			expectedResult: utils.BitVector{0b0010_1111_0010},
+		},
+		{
+			name:           "Explicit flip vector",
+			shape:          merlinsMagicSquare,
+			index:          2,
+			expectedResult: utils.BitVector{0b000_110_110},
+		},
+		{
+			name:           "Explicit flip vector with larger capacity",
+			shape:          Shape{RowCount: 1, ColumnCount: 2, FlipVectors: []utils.BitVector{{0b01, 0b0}, {0b11, 0b0}}},
+			index:          1,
+			expectedResult: utils.BitVector{0b11},
+		},
+		{
+			name:           "Custom neighbourhood",
+			shape:          Shape{RowCount: 3, ColumnCount: 4, Neighbourhood: []Offset{{0, 3}, {2, 0}, {-1, -1}}},
+			index:          0,
+			expectedResult: utils.BitVector{0b0001_0000_1000},
+		},
+		{
+			name:           "Moore neighbourhood on a masked board",
+			shape:          Shape{RowCount: 3, ColumnCount: 3, Neighbourhood: MooreNeighbourhood.Offsets(3, 3), Mask: crossBoard.Mask},
+			index:          4,
+			expectedResult: utils.BitVector{0b010_111_010},
+		},
+		{
+			name:           "Explicit flip vector on a masked board",
+			shape:          Shape{RowCount: 1, ColumnCount: 3, FlipVectors: []utils.BitVector{{0b111}, {0b111}, {0b111}}, Mask: utils.BitVector{0b101}},
+			index:          0,
+			expectedResult: utils.BitVector{0b101},
+		},
+		{
+			name:           "Hexagonal grid in an odd row",
+			shape:          Shape{RowCount: 3, ColumnCount: 3, Grid: HexagonalGrid},
+			index:          4,
+			expectedResult: utils.BitVector{0b110_111_110},
+		},
+		{
+			name:           "Hexagonal grid in the corner",
+			shape:          Shape{RowCount: 3, ColumnCount: 3, Grid: HexagonalGrid},
+			index:          0,
+			expectedResult: utils.BitVector{0b000_001_011},
+		},
+		{
+			name:           "Hexagonal grid in the corner of a torus",
+			shape:          Shape{RowCount: 4, ColumnCount: 4, Topology: ToroidalTopology, Grid: HexagonalGrid},
+			index:          0,
+			expectedResult: utils.BitVector{0b1001_0000_1001_1011},
+		},
+		{
+			name:           "Missing cell",
+			shape:          crossBoard,
+			index:          0,
+			expectedResult: utils.BitVector{0b000_000_000},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Act
+			result := getFlipVector(testCase.shape, testCase.index)
+
+			// Assert
+			if !result.Equal(testCase.expectedResult) {
+				t.Errorf("Incorrect result: expected %b, got %b", testCase.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestSolveBoardOnDifferentShapes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		shape    Shape
+		board    utils.BitVector
+		options  Options
+		solvable bool
+	}{
+		{
+			name:     "1x1 board",
+			shape:    Shape{RowCount: 1, ColumnCount: 1},
+			board:    utils.BitVector{0b1},
+			solvable: true,
+		},
+		{
+			name:     "3x3 board",
+			shape:    Shape{RowCount: 3, ColumnCount: 3},
+			board:    utils.BitVector{0b101_010_101},
+			solvable: true,
+		},
+		{
+			name:     "4x4 board with solution",
+			shape:    Shape{RowCount: 4, ColumnCount: 4},
+			board:    utils.BitVector{0b1001_0000_0000_1001},
+			solvable: true,
+		},
+		{
+			name:     "4x4 board without solution",
+			shape:    Shape{RowCount: 4, ColumnCount: 4},
+			board:    utils.BitVector{0b0000_0000_0000_0001},
+			solvable: false,
+		},
+		{
+			name:     "5x6 board",
+			shape:    Shape{RowCount: 5, ColumnCount: 6},
+			board:    utils.BitVector{0b110011_001100_110011_001100_110011},
+			solvable: true,
+		},
+		{
+			name:     "6x6 board",
+			shape:    Shape{RowCount: 6, ColumnCount: 6},
+			board:    utils.BitVector{0b100001_010010_001100_001100_010010_100001},
+			solvable: true,
+		},
+		{
+			name:     "7x9 board",
+			shape:    Shape{RowCount: 7, ColumnCount: 9},
+			board:    utils.BitVector{0x7fff_ffff_ffff_ffff},
+			solvable: true,
+		},
+		{
+			name:     "10x10 board",
+			shape:    Shape{RowCount: 10, ColumnCount: 10},
+			board:    utils.BitVector{0xdead_beef_dead_beef, 0xf_dead_beef},
+			solvable: true,
+		},
+		{
+			name:     "5x5 toroidal board",
+			shape:    Shape{RowCount: 5, ColumnCount: 5, Topology: ToroidalTopology},
+			board:    utils.BitVector{0b00000_00100_01110_00100_00000},
+			solvable: true,
+		},
+		{
+			name:     "5x5 toroidal board without solution",
+			shape:    Shape{RowCount: 5, ColumnCount: 5, Topology: ToroidalTopology},
+			board:    utils.BitVector{0b00000_00000_00000_00000_00001},
+			solvable: false,
+		},
+		{
+			name:     "6x7 toroidal board",
+			shape:    Shape{RowCount: 6, ColumnCount: 7, Topology: ToroidalTopology},
+			board:    utils.BitVector{0x3ff_ffff_ffff},
+			solvable: true,
+		},
+		{
+			name:     "5x5 board with Moore neighbourhood",
+			shape:    Shape{RowCount: 5, ColumnCount: 5, Neighbourhood: MooreNeighbourhood.Offsets(5, 5)},
+			board:    utils.BitVector{0b10101_01010_10101_01010_10101},
+			solvable: true,
+		},
+		{
+			name:     "5x5 board with X neighbourhood without solution",
+			shape:    Shape{RowCount: 5, ColumnCount: 5, Neighbourhood: XNeighbourhood.Offsets(5, 5)},
+			board:    utils.BitVector{0b00000_00000_00000_00000_00011},
+			solvable: false,
+		},
+		{
+			name:     "6x6 board with knight neighbourhood",
+			shape:    Shape{RowCount: 6, ColumnCount: 6, Neighbourhood: KnightNeighbourhood.Offsets(6, 6)},
+			board:    utils.BitVector{0b111111_000000_111111_000000_111111_000000},
+			solvable: true,
+		},
+		{
+			name:     "4x4 toroidal board with cross neighbourhood",
+			shape:    Shape{RowCount: 4, ColumnCount: 4, Topology: ToroidalTopology, Neighbourhood: CrossNeighbourhood.Offsets(4, 4)},
+			board:    utils.BitVector{0b0001_0010_0100_1000},
+			solvable: true,
+		},
+		{
+			name:     "Merlin's Magic Square",
+			shape:    merlinsMagicSquare,
+			board:    utils.BitVector{0b111_101_111},
+			solvable: true,
+		},
+		{
+			name:     "Explicit flip vectors without solution",
+			shape:    Shape{RowCount: 2, ColumnCount: 2, FlipVectors: []utils.BitVector{{0b0011}, {0b0011}, {0b1100}, {0b1100}}},
+			board:    utils.BitVector{0b0001},
+			solvable: false,
+		},
+		{
+			name:     "9x9 board without solution",
+			shape:    Shape{RowCount: 9, ColumnCount: 9},
+			board:    utils.BitVector{0b1, 0b0},
+			solvable: false,
+		},
+		{
+			name:     "5x5 board to all lights on",
+			shape:    DefaultShape,
+			board:    utils.BitVector{0b0},
+			options:  Options{Target: utils.BitVector{0b11111_11111_11111_11111_11111}},
+			solvable: true,
+		},
+		{
+			name:     "5x5 board to a picture",
+			shape:    DefaultShape,
+			board:    utils.BitVector{0b10001_01010_00100_01010_10001},
+			options:  Options{Target: utils.BitVector{0b00100_00100_11111_00100_00100}},
+			solvable: true,
+		},
+		{
+			name:     "5x5 board to a picture without solution",
+			shape:    DefaultShape,
+			board:    utils.BitVector{0b10001_01010_00100_01010_10001},
+			options:  Options{Target: utils.BitVector{0b00000_00000_00000_00000_00001}},
+			solvable: false,
+		},
+		{
+			name:     "Cross-shaped board",
+			shape:    crossBoard,
+			board:    utils.BitVector{0b010_000_010},
+			solvable: true,
+		},
+		{
+			name:     "L-shaped board",
+			shape:    lShapedBoard,
+			board:    utils.BitVector{0b100_000_001},
+			solvable: true,
+		},
+		{
+			name:     "Diamond board",
+			shape:    diamondBoard,
+			board:    utils.BitVector{0b00100_00000_10001_00000_00100},
+			solvable: true,
+		},
+		{
+			name:     "4x4 hexagonal board",
+			shape:    Shape{RowCount: 4, ColumnCount: 4, Grid: HexagonalGrid},
+			board:    utils.BitVector{0b0000_0000_0000_0001},
+			solvable: true,
+		},
+		{
+			name:     "5x5 hexagonal board",
+			shape:    Shape{RowCount: 5, ColumnCount: 5, Grid: HexagonalGrid},
+			board:    utils.BitVector{0b00000_00000_00100_00000_00000},
+			solvable: true,
+		},
+		{
+			name:     "6x6 toroidal hexagonal board without solution",
+			shape:    Shape{RowCount: 6, ColumnCount: 6, Topology: ToroidalTopology, Grid: HexagonalGrid},
+			board:    utils.BitVector{0b1},
+			solvable: false,
+		},
+		{
+			name:     "Hexagon-shaped board",
+			shape:    Shape{RowCount: 5, ColumnCount: 5, Grid: HexagonalGrid, Mask: utils.BitVector{0b01110_01111_11111_01111_01110}},
+			board:    utils.BitVector{0b00100_00000_10001_00000_00100},
+			solvable: true,
+		},
+		{
+			name:     "Masked 6x6 toroidal board",
+			shape:    Shape{RowCount: 6, ColumnCount: 6, Topology: ToroidalTopology, Mask: utils.BitVector{0b111111_110011_100001_100001_110011_111111}},
+			board:    utils.BitVector{0b000000_000000_000001_000000_000000_000000},
+			solvable: true,
+		},
+	}
+
+	solver := NewBoardSolver(NewGaussianEliminator(), NewFreeVariableFixer(NewBruteForceOptimizer()))
+	enumerator := NewSolutionEnumerator(NewGaussianEliminator())
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Act
+			solvability, solution, _ := solver.SolveBoard(context.Background(), testCase.shape, testCase.board, testCase.options)
+			solvable := solvability == Solvable
+
+			// Assert
+			if solvable != testCase.solvable {
+				t.Fatalf("Incorrect result for solvable: expected %v, got %v", testCase.solvable, solvability)
+			}
+
+			if solvable && !applyClicks(testCase.shape, testCase.board, solution).Equal(testCase.options.Target) {
+				t.Errorf("Incorrect result for solution: %b does not reach the target", solution)
+			}
+
+			for i := 0; solvable && i < testCase.shape.CellCount(); i++ {
+				if solution.TestBit(i) && !testCase.shape.HasCell(i) {
+					t.Errorf("Incorrect result for solution: %b clicks the missing cell %v", solution, i)
+				}
+			}
+
+			// The boards without solution have to come with a proof of it
+			if certificate, unsolvable := enumerator.Certificate(testCase.shape, testCase.board, testCase.options); unsolvable == solvable {
+				t.Errorf("Incorrect result for certificate: expected unsolvable %v, got %v", !solvable, unsolvable)
+			} else if unsolvable && !provesUnsolvability(testCase.shape, testCase.board, testCase.options, certificate.Witness()) {
+				t.Errorf("Incorrect result for certificate: %b does not prove that the board is unsolvable", certificate.Witness())
+			}
+		})
+	}
+}
+
+func TestSolveBoardWithOptions(t *testing.T) {
+	testCases := []struct {
+		name        string
+		shape       Shape
+		board       utils.BitVector
+		options     Options
+		solvability Solvability
+	}{
+		{
+			name:        "Only the center has to be turned off",
+			shape:       Shape{RowCount: 3, ColumnCount: 3},
+			board:       utils.BitVector{0b111_111_111},
+			options:     Options{TargetMask: utils.BitVector{0b000_010_000}},
+			solvability: Solvable,
+		},
+		{
+			name:        "Corners have to be turned off",
+			shape:       Shape{RowCount: 3, ColumnCount: 3},
+			board:       utils.BitVector{0b101_010_101},
+			options:     Options{TargetMask: utils.BitVector{0b101_000_101}},
+			solvability: Solvable,
+		},
+		{
+			name:        "Don't-care cells on a 4x4 board without solution",
+			shape:       Shape{RowCount: 4, ColumnCount: 4},
+			board:       utils.BitVector{0b0000_0000_0000_0001},
+			options:     Options{TargetMask: utils.BitVector{0b1111_1111_1111_0111}},
+			solvability: Unsolvable,
+		},
+		{
+			name:        "Don't-care cells making a 4x4 board solvable",
+			shape:       Shape{RowCount: 4, ColumnCount: 4},
+			board:       utils.BitVector{0b0000_0000_0000_0001},
+			options:     Options{TargetMask: utils.BitVector{0b1111_1011_1111_1111}},
+			solvability: Solvable,
+		},
+		{
+			name:        "Target with don't-care cells",
+			shape:       Shape{RowCount: 2, ColumnCount: 4},
+			board:       utils.BitVector{0b0110_1001},
+			options:     Options{Target: utils.BitVector{0b1111_0000}, TargetMask: utils.BitVector{0b1100_0011}},
+			solvability: Solvable,
+		},
+		{
+			name:        "No cell has to match the target",
+			shape:       Shape{RowCount: 2, ColumnCount: 2},
+			board:       utils.BitVector{0b1011},
+			options:     Options{TargetMask: utils.BitVector{0b0000}},
+			solvability: Solvable,
+		},
+		{
+			name:        "Expensive center",
+			shape:       Shape{RowCount: 3, ColumnCount: 3},
+			board:       utils.BitVector{0b010_111_010},
+			options:     Options{Costs: []int{1, 1, 1, 1, 10, 1, 1, 1, 1}},
+			solvability: Solvable,
+		},
+		{
+			name:        "Expensive edges on a 4x4 board",
+			shape:       Shape{RowCount: 4, ColumnCount: 4},
+			board:       utils.BitVector{0b0110_1001_1001_0110},
+			options:     Options{Costs: []int{5, 5, 5, 5, 5, 1, 1, 5, 5, 1, 1, 5, 5, 5, 5, 5}},
+			solvability: Solvable,
+		},
+		{
+			name:        "Free clicks on a 4x5 board",
+			shape:       Shape{RowCount: 4, ColumnCount: 5},
+			board:       utils.BitVector{0b11111_00000_00000_11111},
+			options:     Options{Costs: []int{0, 0, 0, 0, 0, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 3, 3, 3, 3, 3}},
+			solvability: Solvable,
+		},
+		{
+			name:        "Costs on a masked board",
+			shape:       crossBoard,
+			board:       utils.BitVector{0b010_101_010},
+			options:     Options{Costs: []int{0, 2, 0, 1, 5, 1, 0, 2, 0}},
+			solvability: Solvable,
+		},
+		{
+			name:        "Costs with don't-care cells",
+			shape:       Shape{RowCount: 3, ColumnCount: 3},
+			board:       utils.BitVector{0b100_000_001},
+			options:     Options{TargetMask: utils.BitVector{0b111_000_111}, Costs: []int{3, 1, 3, 1, 1, 1, 3, 1, 3}},
+			solvability: Solvable,
+		},
+		{
+			name:        "Forbidden center on a 3x3 board with a single solution",
+			shape:       Shape{RowCount: 3, ColumnCount: 3},
+			board:       utils.BitVector{0b010_111_010},
+			options:     Options{ForbiddenClicks: utils.BitVector{0b000_010_000}},
+			solvability: UnsolvableUnderConstraints,
+		},
+		{
+			name:        "Mandatory corner on a 3x3 board with a single solution",
+			shape:       Shape{RowCount: 3, ColumnCount: 3},
+			board:       utils.BitVector{0b010_111_010},
+			options:     Options{MandatoryClicks: utils.BitVector{0b000_000_001}},
+			solvability: UnsolvableUnderConstraints,
+		},
+		{
+			name:        "Forbidden clicks on a 4x4 board without solution",
+			shape:       Shape{RowCount: 4, ColumnCount: 4},
+			board:       utils.BitVector{0b0000_0000_0000_0001},
+			options:     Options{ForbiddenClicks: utils.BitVector{0b0000_0000_0000_0010}},
+			solvability: Unsolvable,
+		},
+		{
+			name:        "Locked center on a 4x4 board",
+			shape:       Shape{RowCount: 4, ColumnCount: 4},
+			board:       utils.BitVector{0b0110_1001_1001_0110},
+			options:     Options{ForbiddenClicks: utils.BitVector{0b0000_0110_0110_0000}},
+			solvability: Solvable,
+		},
+		{
+			name:        "Locked first row on a 4x4 board",
+			shape:       Shape{RowCount: 4, ColumnCount: 4},
+			board:       utils.BitVector{0b1100_1000_0000_0000},
+			options:     Options{ForbiddenClicks: utils.BitVector{0b0000_0000_0000_1111}},
+			solvability: Solvable,
+		},
+		{
+			name:        "Mandatory corners on a 4x4 board",
+			shape:       Shape{RowCount: 4, ColumnCount: 4},
+			board:       utils.BitVector{0b0110_1001_1001_0110},
+			options:     Options{MandatoryClicks: utils.BitVector{0b1001_0000_0000_1001}},
+			solvability: Solvable,
+		},
+		{
+			name:        "Forbidden and mandatory clicks on a 4x5 board",
+			shape:       Shape{RowCount: 4, ColumnCount: 5},
+			board:       utils.BitVector{0b00100_01110_00100_00000},
+			options:     Options{ForbiddenClicks: utils.BitVector{0b00000_00000_00100_00000}, MandatoryClicks: utils.BitVector{0b00000_00000_00000_00001}},
+			solvability: UnsolvableUnderConstraints,
+		},
+		{
+			name:        "Forbidden clicks on a masked board",
+			shape:       crossBoard,
+			board:       utils.BitVector{0b010_101_010},
+			options:     Options{ForbiddenClicks: utils.BitVector{0b000_010_000}},
+			solvability: Solvable,
+		},
+		{
+			name:        "Constraints with costs and don't-care cells",
+			shape:       Shape{RowCount: 3, ColumnCount: 3},
+			board:       utils.BitVector{0b100_000_001},
+			options:     Options{TargetMask: utils.BitVector{0b111_000_111}, Costs: []int{3, 1, 3, 1, 1, 1, 3, 1, 3}, ForbiddenClicks: utils.BitVector{0b000_000_010}, MandatoryClicks: utils.BitVector{0b000_100_000}},
+			solvability: Solvable,
+		},
+		{
+			name:        "Tie-breaking with constraints and costs",
+			shape:       Shape{RowCount: 4, ColumnCount: 4},
+			board:       tiedBoard,
+			options:     Options{ForbiddenClicks: utils.BitVector{0b0000_0000_0000_0100}, Costs: []int{1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2}, TieBreaking: FewestRowsTieBreaking},
+			solvability: Solvable,
+		},
+	}
+
+	solver := NewBoardSolver(NewGaussianEliminator(), NewFreeVariableFixer(NewBruteForceOptimizer()))
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Arrange
+			expectedCost := findOptimalCost(testCase.shape, testCase.board, testCase.options)
+
+			// Act
+			solvability, solution, _ := solver.SolveBoard(context.Background(), testCase.shape, testCase.board, testCase.options)
+
+			// Assert
+			if solvability != testCase.solvability {
+				t.Fatalf("Incorrect result for solvability: expected %v, got %v", testCase.solvability, solvability)
+			}
+
+			if solvability != Solvable {
+				if expectedCost >= 0 {
+					t.Errorf("Incorrect result for solvability: the board can be solved with a cost of %v", expectedCost)
+				}
+				return
+			}
+
+			if !reachesTarget(testCase.shape, testCase.board, testCase.options, solution) {
+				t.Errorf("Incorrect result for solution: %b does not reach the target", solution)
+			}
+
+			if !satisfiesConstraints(testCase.shape, testCase.options, solution) {
+				t.Errorf("Incorrect result for solution: %b does not satisfy the constraints", solution)
+			}
+
+			if cost := totalCost(testCase.shape, testCase.options, solution); cost != expectedCost {
+				t.Errorf("Incorrect result for solution: expected a cost of %v, got %v (%b)", expectedCost, cost, solution)
+			}
+		})
+	}
+}
+
+func TestSolveBoardBeforeDeadline(t *testing.T) {
+	testCases := []struct {
+		name      string
+		optimizer Optimizer
+		timeout   time.Duration
+		optimal   bool
+	}{
+		{
+			name:      "Brute force with a done context",
+			optimizer: NewBruteForceOptimizer(),
+			timeout:   0,
+			optimal:   false,
+		},
+		{
+			name:      "Brute force with a deadline",
+			optimizer: NewBruteForceOptimizer(),
+			timeout:   10 * time.Millisecond,
+			optimal:   false,
+		},
+		{
+			name:      "Branch and bound with enough time",
+			optimizer: NewBranchAndBoundOptimizer(),
+			timeout:   time.Minute,
+			optimal:   true,
+		},
+		{
+			name:      "Branch and bound with a done context",
+			optimizer: NewBranchAndBoundOptimizer(),
+			timeout:   0,
+			optimal:   false,
+		},
+	}
+
+	// The 30x30 torus has 24 free variables, so they cannot all be tried before the short deadlines
+	shape := Shape{RowCount: 30, ColumnCount: 30, Topology: ToroidalTopology}
+	board := getPatternBoard(shape, 0)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Arrange
+			solver := NewBoardSolver(NewGaussianEliminator(), NewFreeVariableFixer(testCase.optimizer))
+			ctx, cancel := context.WithTimeout(context.Background(), testCase.timeout)
+			defer cancel()
+
+			// Act
+			solvability, solution, optimal := solver.SolveBoard(ctx, shape, board, Options{})
+
+			// Assert
+			if solvability != Solvable {
+				t.Fatalf("Incorrect solvability: expected %v, got %v", Solvable, solvability)
+			}
+
+			if !reachesTarget(shape, board, Options{}, solution) {
+				t.Error("The best solution found does not turn off the lights")
+			}
+
+			if optimal != testCase.optimal {
+				t.Errorf("Incorrect optimality: expected %v, got %v", testCase.optimal, optimal)
+			}
+		})
+	}
+}
+
+// Tries every possible set of clicks, and returns the lowest cost that reaches the target
+func findOptimalCost(shape Shape, board utils.BitVector, options Options) int {
+	optimalCost := -1
+	for clicks := uint64(0); clicks < 1<<shape.CellCount(); clicks++ {
+		solution := utils.BitVector{clicks}
+		if !reachesTarget(shape, board, options, solution) || !satisfiesConstraints(shape, options, solution) {
+			continue
+		}
+
+		if cost := totalCost(shape, options, solution); optimalCost < 0 || cost < optimalCost {
+			optimalCost = cost
+		}
+	}
+
+	return optimalCost
+}
+
+func totalCost(shape Shape, options Options, clicks utils.BitVector) (cost int) {
+	if len(options.Costs) == 0 {
+		return clicks.OnesCount()
+	}
+
+	for i := 0; i < shape.CellCount(); i++ {
+		if clicks.TestBit(i) {
+			cost += options.Costs[i]
+		}
+	}
+
+	return cost
+}
+
+// Checks that none of the forbidden cells, but all of the mandatory ones are clicked
+func satisfiesConstraints(shape Shape, options Options, clicks utils.BitVector) bool {
+	for i := 0; i < shape.CellCount(); i++ {
+		if options.isForbidden(i) && clicks.TestBit(i) || options.isMandatory(i) && !clicks.TestBit(i) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Checks whether the clicks bring the cells of the target mask to their target state
+func reachesTarget(shape Shape, board utils.BitVector, options Options, clicks utils.BitVector) bool {
+	result := applyClicks(shape, board, clicks)
+	result.Xor(options.toggledCells(shape, utils.NewBitVector(shape.CellCount())))
+	if len(options.TargetMask) > 0 {
+		result.And(options.TargetMask)
+	}
+
+	return result.IsZero()
+}
+
+// Applies the given clicks to the board, returning the resulting board
+func applyClicks(shape Shape, board utils.BitVector, clicks utils.BitVector) utils.BitVector {
+	result := utils.NewBitVector(shape.CellCount() + 1)
+	result.Xor(board)
+
+	for i := 0; i < shape.CellCount(); i++ {
+		if clicks.TestBit(i) {
+			result.Xor(getFlipVector(shape, i))
+		}
+	}
+
+	return result
+}
+
+func benchmarkSolveBoard(b *testing.B, solver BoardSolver) {
 	testCases := []struct {
 		name  string
-		board uint32
+		board utils.BitVector
 	}{
 		{
 			name:  "Board with solution",
-			board: 0b11011_10101_01010_10101_11011,
+			board: utils.BitVector{0b11011_10101_01010_10101_11011},
 		},
 		{
 			name:  "Board with no solution",
-			board: 0b10001_00000_00000_00000_00001,
+			board: utils.BitVector{0b10001_00000_00000_00000_00001},
 		},
 	}
 
-	freeVariableFixer := NewFreeVariableFixer(optimizer)
-	gaussianEliminator := NewGaussianEliminator()
-	solver := NewBoardSolver(gaussianEliminator, freeVariableFixer)
-
 	for _, testCase := range testCases {
 		b.Run(testCase.name, func(b *testing.B) {
 			for i := 0; i < b.N; i++ {
-				solver.SolveBoard(testCase.board)
+				solver.SolveBoard(context.Background(), DefaultShape, testCase.board, Options{})
 			}
 		})
 	}
@@ -306,10 +1164,10 @@ func benchmarkSolveBoard(b *testing.B, optimizer Optimizer) {
 
 func BenchmarkSolveBoardWithoutOptimizer(b *testing.B) {
 	optimizer := NewZeroValueOptimizer()
-	benchmarkSolveBoard(b, optimizer)
+	benchmarkSolveBoard(b, NewBoardSolver(NewGaussianEliminator(), NewFreeVariableFixer(optimizer)))
 }
 
 func BenchmarkSolveBoardWithOptimizer(b *testing.B) {
 	optimizer := NewBruteForceOptimizer()
-	benchmarkSolveBoard(b, optimizer)
+	benchmarkSolveBoard(b, NewBoardSolver(NewGaussianEliminator(), NewFreeVariableFixer(optimizer)))
 }
@@ -0,0 +1,267 @@
+package solver
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+	"server/utils"
+	"sync"
+)
+
+// The upper limit on the number of cells of a board that has a solution table, as it holds an entry for every board
+const MaxTableCellCount = 25
+
+// The format of the solution table files:
+//   - the magic bytes and the version of the format
+//   - the number of rows and columns of the board, in a byte each
+//   - an entry for every board in the order of their numbers, holding the clicks of the solution, or all ones if there is none
+//   - the CRC-32 checksum of everything before it
+//
+// The numbers are stored as little-endian unsigned integers, the version in 2 bytes, the entries and the checksum in 4 bytes.
+const (
+	tableMagic   = "MZLT"
+	TableVersion = 1
+)
+
+// The entry of the boards without a solution, which cannot be a solution, as it has more bits than the cells of any board in a table
+const unsolvableEntry = math.MaxUint32
+
+// The number of entries encoded or decoded at once
+const tableChunkSize = 1 << 12
+
+// SolutionTable holds the solution of every board of a rectangular shape with the default rules, computed in advance
+type SolutionTable struct {
+	shape   Shape
+	entries []uint32
+}
+
+// BuildSolutionTable solves every board of the shape with the board solver, using the given number of goroutines
+func BuildSolutionTable(shape Shape, boardSolver BoardSolver, workerCount int) (*SolutionTable, error) {
+	if err := validateTableShape(shape); err != nil {
+		return nil, err
+	}
+
+	if workerCount < 1 {
+		return nil, errors.New("at least one worker is needed")
+	}
+
+	table := &SolutionTable{shape: shape, entries: make([]uint32, 1<<shape.CellCount())}
+
+	// The workers take turns in solving the chunks of the boards, so they all finish at about the same time
+	var wg sync.WaitGroup
+	for worker := 0; worker < workerCount; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+
+			for start := worker * tableChunkSize; start < len(table.entries); start += workerCount * tableChunkSize {
+				for board := start; board < start+tableChunkSize && board < len(table.entries); board++ {
+					table.entries[board] = solveTableEntry(shape, boardSolver, board)
+				}
+			}
+		}(worker)
+	}
+	wg.Wait()
+
+	return table, nil
+}
+
+func solveTableEntry(shape Shape, boardSolver BoardSolver, board int) uint32 {
+	solvability, solution, _ := boardSolver.SolveBoard(context.Background(), shape, utils.BitVector{uint64(board)}, Options{})
+	if solvability != Solvable {
+		return unsolvableEntry
+	}
+
+	return uint32(solution[0])
+}
+
+// Only the plain rectangles have a table, as the tables are looked up by the number of rows and columns
+func validateTableShape(shape Shape) error {
+	if err := shape.Validate(); err != nil {
+		return err
+	}
+
+	if !isPlainRectangle(shape) {
+		return errors.New("only planar square grids with the default neighbourhood can have a solution table")
+	}
+
+	if shape.CellCount() > MaxTableCellCount {
+		return fmt.Errorf("the board of a solution table can have at most %v cells", MaxTableCellCount)
+	}
+
+	return nil
+}
+
+func isPlainRectangle(shape Shape) bool {
+	return shape.Topology == PlanarTopology && shape.Grid == SquareGrid &&
+		len(shape.Neighbourhood) == 0 && len(shape.FlipVectors) == 0 && len(shape.Mask) == 0
+}
+
+// Verify checks that the clicks of every entry turn off all the lights of its board
+func (t *SolutionTable) Verify() error {
+	flipVectors := make([]uint32, t.shape.CellCount())
+	for i := range flipVectors {
+		flipVectors[i] = uint32(getFlipVector(t.shape, i)[0])
+	}
+
+	for board, entry := range t.entries {
+		if entry == unsolvableEntry {
+			continue
+		}
+
+		result := uint32(board)
+		for i, flipVector := range flipVectors {
+			if entry&(1<<i) != 0 {
+				result ^= flipVector
+			}
+		}
+
+		if result != 0 {
+			return fmt.Errorf("the entry %b of board %b is not a solution", entry, board)
+		}
+	}
+
+	return nil
+}
+
+func (t *SolutionTable) Shape() Shape {
+	return t.shape
+}
+
+// Lookup returns the solution of the board from the table
+func (t *SolutionTable) Lookup(board utils.BitVector) (Solvability, utils.BitVector) {
+	entry := t.entries[board[0]&(1<<t.shape.CellCount()-1)]
+	if entry == unsolvableEntry {
+		return Unsolvable, nil
+	}
+
+	return Solvable, utils.BitVector{uint64(entry)}
+}
+
+// WriteTo writes the table in the format of the solution table files
+func (t *SolutionTable) WriteTo(w io.Writer) (int64, error) {
+	checksum := crc32.NewIEEE()
+	writer := bufio.NewWriter(io.MultiWriter(w, checksum))
+
+	header := make([]byte, 0, len(tableMagic)+4)
+	header = append(header, tableMagic...)
+	header = binary.LittleEndian.AppendUint16(header, TableVersion)
+	header = append(header, byte(t.shape.RowCount), byte(t.shape.ColumnCount))
+
+	if _, err := writer.Write(header); err != nil {
+		return 0, err
+	}
+
+	buffer := make([]byte, 4*tableChunkSize)
+	for start := 0; start < len(t.entries); start += tableChunkSize {
+		chunk := buffer[:0]
+		for i := start; i < start+tableChunkSize && i < len(t.entries); i++ {
+			chunk = binary.LittleEndian.AppendUint32(chunk, t.entries[i])
+		}
+
+		if _, err := writer.Write(chunk); err != nil {
+			return 0, err
+		}
+	}
+
+	// The checksum is only complete once everything before it has been written
+	if err := writer.Flush(); err != nil {
+		return 0, err
+	}
+
+	if _, err := w.Write(binary.LittleEndian.AppendUint32(nil, checksum.Sum32())); err != nil {
+		return 0, err
+	}
+
+	return int64(len(header) + 4*len(t.entries) + 4), nil
+}
+
+// ReadSolutionTable reads a table in the format of the solution table files, verifying its version and checksum
+func ReadSolutionTable(r io.Reader) (*SolutionTable, error) {
+	checksum := crc32.NewIEEE()
+	reader := io.TeeReader(bufio.NewReader(r), checksum)
+
+	header := make([]byte, len(tableMagic)+4)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return nil, fmt.Errorf("cannot read the header of the solution table: %w", err)
+	}
+
+	if string(header[:len(tableMagic)]) != tableMagic {
+		return nil, errors.New("not a solution table")
+	}
+
+	if version := binary.LittleEndian.Uint16(header[len(tableMagic):]); version != TableVersion {
+		return nil, fmt.Errorf("unsupported solution table version %v, expected %v", version, TableVersion)
+	}
+
+	shape := Shape{RowCount: int(header[len(tableMagic)+2]), ColumnCount: int(header[len(tableMagic)+3])}
+	if err := validateTableShape(shape); err != nil {
+		return nil, err
+	}
+
+	table := &SolutionTable{shape: shape, entries: make([]uint32, 1<<shape.CellCount())}
+
+	buffer := make([]byte, 4*tableChunkSize)
+	for start := 0; start < len(table.entries); start += tableChunkSize {
+		chunk := buffer[:4*tableChunkSize]
+		if remaining := len(table.entries) - start; remaining < tableChunkSize {
+			chunk = buffer[:4*remaining]
+		}
+
+		if _, err := io.ReadFull(reader, chunk); err != nil {
+			return nil, fmt.Errorf("cannot read the entries of the solution table: %w", err)
+		}
+
+		for i := 0; i < len(chunk); i += 4 {
+			table.entries[start+i/4] = binary.LittleEndian.Uint32(chunk[i:])
+		}
+	}
+
+	expectedChecksum := checksum.Sum32()
+	checksumBytes := make([]byte, 4)
+	if _, err := io.ReadFull(reader, checksumBytes); err != nil {
+		return nil, fmt.Errorf("cannot read the checksum of the solution table: %w", err)
+	}
+
+	if binary.LittleEndian.Uint32(checksumBytes) != expectedChecksum {
+		return nil, errors.New("the checksum of the solution table does not match")
+	}
+
+	if n, _ := reader.Read(checksumBytes); n > 0 {
+		return nil, errors.New("unexpected data after the checksum of the solution table")
+	}
+
+	return table, nil
+}
+
+type tableSolver struct {
+	table       *SolutionTable
+	boardSolver BoardSolver
+}
+
+// NewTableSolver returns a board solver that looks up the solutions of the boards of the table,
+// and solves the rest of them with the board solver
+func NewTableSolver(table *SolutionTable, boardSolver BoardSolver) BoardSolver {
+	return &tableSolver{table: table, boardSolver: boardSolver}
+}
+
+func (s *tableSolver) SolveBoard(ctx context.Context, shape Shape, board utils.BitVector, options Options) (Solvability, utils.BitVector, bool) {
+	if !s.hasBoard(shape, options) {
+		return s.boardSolver.SolveBoard(ctx, shape, board, options)
+	}
+
+	solvability, solution := s.table.Lookup(board)
+	return solvability, solution, true
+}
+
+// Reports whether the table holds the solution, which is only the case for the shape of the table without any options
+func (s *tableSolver) hasBoard(shape Shape, options Options) bool {
+	return isPlainRectangle(shape) && shape.RowCount == s.table.shape.RowCount && shape.ColumnCount == s.table.shape.ColumnCount &&
+		len(options.Target) == 0 && len(options.TargetMask) == 0 && len(options.Costs) == 0 &&
+		!options.hasClickConstraints() && options.TieBreaking == NoTieBreaking
+}
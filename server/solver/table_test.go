@@ -0,0 +1,291 @@
+package solver
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"server/utils"
+	"testing"
+)
+
+func TestBuildSolutionTable(t *testing.T) {
+	testCases := []struct {
+		name        string
+		shape       Shape
+		workerCount int
+	}{
+		{
+			name:        "3x3 board",
+			shape:       Shape{RowCount: 3, ColumnCount: 3},
+			workerCount: 1,
+		},
+		{
+			name:        "4x4 board",
+			shape:       Shape{RowCount: 4, ColumnCount: 4},
+			workerCount: 4,
+		},
+		{
+			name:        "3x5 board",
+			shape:       Shape{RowCount: 3, ColumnCount: 5},
+			workerCount: 3,
+		},
+	}
+
+	boardSolver := NewBoardSolver(NewGaussianEliminator(), NewFreeVariableFixer(NewBruteForceOptimizer()))
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Act
+			table, err := BuildSolutionTable(testCase.shape, boardSolver, testCase.workerCount)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if err := table.Verify(); err != nil {
+				t.Errorf("Incorrect table: %v", err)
+			}
+
+			for board := uint64(0); board < 1<<testCase.shape.CellCount(); board++ {
+				expectedSolvability, expectedSolution, _ := boardSolver.SolveBoard(context.Background(), testCase.shape, utils.BitVector{board}, Options{})
+				solvability, solution := table.Lookup(utils.BitVector{board})
+
+				if solvability != expectedSolvability || !reflect.DeepEqual(solution, expectedSolution) {
+					t.Fatalf("Incorrect entry for board %b: expected %v %v, got %v %v", board, expectedSolvability, expectedSolution, solvability, solution)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildSolutionTableValidation(t *testing.T) {
+	testCases := []struct {
+		name        string
+		shape       Shape
+		workerCount int
+	}{
+		{
+			name:        "Too many cells",
+			shape:       Shape{RowCount: 6, ColumnCount: 5},
+			workerCount: 1,
+		},
+		{
+			name:        "Torus",
+			shape:       Shape{RowCount: 3, ColumnCount: 3, Topology: ToroidalTopology},
+			workerCount: 1,
+		},
+		{
+			name:        "Masked board",
+			shape:       crossBoard,
+			workerCount: 1,
+		},
+		{
+			name:        "Invalid shape",
+			shape:       Shape{RowCount: 0, ColumnCount: 3},
+			workerCount: 1,
+		},
+		{
+			name:        "No workers",
+			shape:       Shape{RowCount: 3, ColumnCount: 3},
+			workerCount: 0,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Act
+			_, err := BuildSolutionTable(testCase.shape, nil, testCase.workerCount)
+
+			// Assert
+			if err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestVerifySolutionTable(t *testing.T) {
+	// Arrange
+	shape := Shape{RowCount: 2, ColumnCount: 2}
+	table, _ := BuildSolutionTable(shape, NewBoardSolver(NewGaussianEliminator(), NewFreeVariableFixer(NewZeroValueOptimizer())), 1)
+	table.entries[0b01_10] ^= 0b10_00
+
+	// Act
+	err := table.Verify()
+
+	// Assert
+	if err == nil {
+		t.Error("Expected an error for an incorrect entry")
+	}
+}
+
+func TestSolutionTableFile(t *testing.T) {
+	// Arrange
+	shape := Shape{RowCount: 3, ColumnCount: 4}
+	table, _ := BuildSolutionTable(shape, NewBoardSolver(NewGaussianEliminator(), NewFreeVariableFixer(NewBruteForceOptimizer())), 2)
+
+	var buffer bytes.Buffer
+
+	// Act
+	written, writeErr := table.WriteTo(&buffer)
+	readTable, readErr := ReadSolutionTable(bytes.NewReader(buffer.Bytes()))
+
+	// Assert
+	if writeErr != nil || readErr != nil {
+		t.Fatalf("Unexpected errors: %v, %v", writeErr, readErr)
+	}
+
+	if expectedSize := int64(8 + 4<<shape.CellCount() + 4); written != expectedSize || int64(buffer.Len()) != expectedSize {
+		t.Errorf("Incorrect size of the file: expected %v, got %v (%v written)", expectedSize, buffer.Len(), written)
+	}
+
+	if !reflect.DeepEqual(readTable, table) {
+		t.Error("Incorrect table read from the file")
+	}
+}
+
+func TestReadInvalidSolutionTable(t *testing.T) {
+	shape := Shape{RowCount: 2, ColumnCount: 3}
+	table, _ := BuildSolutionTable(shape, NewBoardSolver(NewGaussianEliminator(), NewFreeVariableFixer(NewBruteForceOptimizer())), 1)
+
+	var buffer bytes.Buffer
+	table.WriteTo(&buffer)
+	valid := buffer.Bytes()
+
+	// Returns a copy of the valid file, modified by the function
+	modified := func(modify func(file []byte) []byte) []byte {
+		file := make([]byte, len(valid))
+		copy(file, valid)
+		return modify(file)
+	}
+
+	testCases := []struct {
+		name string
+		file []byte
+	}{
+		{
+			name: "Empty file",
+			file: []byte{},
+		},
+		{
+			name: "Incorrect magic bytes",
+			file: modified(func(file []byte) []byte { file[0] = 'X'; return file }),
+		},
+		{
+			name: "Unsupported version",
+			file: modified(func(file []byte) []byte { file[4] = TableVersion + 1; return file }),
+		},
+		{
+			name: "Too many cells",
+			file: modified(func(file []byte) []byte { file[6] = 6; file[7] = 5; return file }),
+		},
+		{
+			name: "Corrupted entry",
+			file: modified(func(file []byte) []byte { file[12] ^= 1; return file }),
+		},
+		{
+			name: "Corrupted checksum",
+			file: modified(func(file []byte) []byte { file[len(file)-1] ^= 1; return file }),
+		},
+		{
+			name: "Truncated file",
+			file: modified(func(file []byte) []byte { return file[:len(file)-8] }),
+		},
+		{
+			name: "Data after the checksum",
+			file: modified(func(file []byte) []byte { return append(file, 0) }),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Act
+			_, err := ReadSolutionTable(bytes.NewReader(testCase.file))
+
+			// Assert
+			if err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestTableSolver(t *testing.T) {
+	testCases := []struct {
+		name      string
+		shape     Shape
+		board     utils.BitVector
+		options   Options
+		fromTable bool
+	}{
+		{
+			name:      "Board of the table",
+			shape:     Shape{RowCount: 3, ColumnCount: 3},
+			board:     utils.BitVector{0b010_111_010},
+			fromTable: true,
+		},
+		{
+			name:      "Board of the table without solution",
+			shape:     Shape{RowCount: 3, ColumnCount: 3},
+			board:     utils.BitVector{0b000_000_001},
+			fromTable: true,
+		},
+		{
+			name:      "Board of a different size",
+			shape:     Shape{RowCount: 3, ColumnCount: 4},
+			board:     utils.BitVector{0b0100_1110_0100},
+			fromTable: false,
+		},
+		{
+			name:      "Torus",
+			shape:     Shape{RowCount: 3, ColumnCount: 3, Topology: ToroidalTopology},
+			board:     utils.BitVector{0b010_111_010},
+			fromTable: false,
+		},
+		{
+			name:      "Target",
+			shape:     Shape{RowCount: 3, ColumnCount: 3},
+			board:     utils.BitVector{0b010_111_010},
+			options:   Options{Target: utils.BitVector{0b000_000_001}},
+			fromTable: false,
+		},
+		{
+			name:      "Constraints",
+			shape:     Shape{RowCount: 3, ColumnCount: 3},
+			board:     utils.BitVector{0b010_111_010},
+			options:   Options{ForbiddenClicks: utils.BitVector{0b000_010_000}},
+			fromTable: false,
+		},
+	}
+
+	boardSolver := NewBoardSolver(NewGaussianEliminator(), NewFreeVariableFixer(NewBruteForceOptimizer()))
+	table, _ := BuildSolutionTable(Shape{RowCount: 3, ColumnCount: 3}, boardSolver, 1)
+
+	// The entries of the table are flipped, so it can be told whether the solution comes from the table
+	for board, entry := range table.entries {
+		if entry != unsolvableEntry {
+			table.entries[board] = entry ^ 0b111_111_111
+		}
+	}
+
+	tableSolver := NewTableSolver(table, boardSolver)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Arrange
+			expectedSolvability, expectedSolution, _ := boardSolver.SolveBoard(context.Background(), testCase.shape, testCase.board, testCase.options)
+			if testCase.fromTable && expectedSolvability == Solvable {
+				expectedSolution = utils.BitVector{expectedSolution[0] ^ 0b111_111_111}
+			}
+
+			// Act
+			solvability, solution, _ := tableSolver.SolveBoard(context.Background(), testCase.shape, testCase.board, testCase.options)
+
+			// Assert
+			if solvability != expectedSolvability || !reflect.DeepEqual(solution, expectedSolution) {
+				t.Errorf("Incorrect result: expected %v %b, got %v %b", expectedSolvability, expectedSolution, solvability, solution)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"server/utils"
+	"sort"
+)
+
+// TieBreaking is the rule that chooses between the solutions with the same lowest cost
+type TieBreaking int
+
+const (
+	// The first optimal solution found by the optimizer is kept
+	NoTieBreaking TieBreaking = iota
+	// The solution whose list of clicked cells comes first in lexicographic order
+	LexicographicTieBreaking
+	// The solution that clicks cells in the fewest rows, then the lexicographic order
+	FewestRowsTieBreaking
+	// The solution whose clicks have the lowest total squared distance from the centre of the board,
+	// then the lexicographic order
+	CentreTieBreaking
+)
+
+func (t TieBreaking) Validate() error {
+	if t < NoTieBreaking || t > CentreTieBreaking {
+		return fmt.Errorf("unknown tie-breaking rule %v", int(t))
+	}
+
+	return nil
+}
+
+// Returns every solution with the lowest cost, ordered by the tie-breaking rule of the options, so with a rule
+// the first one is the solution that SolveBoard returns with the same options. Without one they are in the order they are enumerated,
+// which can differ from the order that the free variable fixer chooses the solution of SolveBoard in.
+func (s SolutionSpace) OptimalSolutions(shape Shape, options Options) ([]utils.BitVector, error) {
+	if s.Dimension() > MaxSortedDimension {
+		return nil, fmt.Errorf("only solution spaces with at most %v dimensions can be searched for all optimal solutions", MaxSortedDimension)
+	}
+
+	optimalCost := -1
+	solutions := make([]utils.BitVector, 0)
+	s.Enumerate(func(solution utils.BitVector) bool {
+		cost := options.solutionCost(shape, solution)
+		if optimalCost < 0 || cost < optimalCost {
+			optimalCost = cost
+			solutions = solutions[:0]
+		}
+
+		if cost == optimalCost {
+			solutions = append(solutions, solution)
+		}
+
+		return true
+	})
+
+	sort.SliceStable(solutions, func(i, j int) bool {
+		return options.breakTie(shape, solutions[i], solutions[j]) < 0
+	})
+
+	return solutions, nil
+}
+
+// Returns the solution with the lowest cost, choosing between the ties by the tie-breaking rule of the options.
+// Only the best solution is kept during the enumeration, so it can search any solution space until the context is done,
+// in which case it returns the best solution found so far, and false as it is not proven optimal.
+func (s SolutionSpace) OptimalSolution(ctx context.Context, shape Shape, options Options) (utils.BitVector, bool) {
+	var optimalSolution utils.BitVector
+	optimalCost := -1
+	finished := true
+
+	enumerated := 0
+	s.Enumerate(func(solution utils.BitVector) bool {
+		if enumerated > 0 && enumerated%contextCheckInterval == 0 && ctx.Err() != nil {
+			finished = false
+			return false
+		}
+		enumerated++
+
+		cost := options.solutionCost(shape, solution)
+		if optimalCost < 0 || cost < optimalCost || cost == optimalCost && options.breakTie(shape, solution, optimalSolution) < 0 {
+			optimalCost = cost
+			optimalSolution = solution
+		}
+
+		return true
+	})
+
+	return optimalSolution, finished
+}
+
+// Returns the total cost of the clicks of the solution
+func (o Options) solutionCost(shape Shape, solution utils.BitVector) int {
+	if len(o.Costs) == 0 {
+		return solution.OnesCount()
+	}
+
+	cost := 0
+	for i := 0; i < shape.CellCount(); i++ {
+		if solution.TestBit(i) {
+			cost += o.Costs[i]
+		}
+	}
+
+	return cost
+}
+
+// Compares two solutions with the same cost, returns a negative number if the first one is preferred,
+// a positive one if the second one, and zero if neither of them, which is always the case without tie-breaking
+func (o Options) breakTie(shape Shape, a, b utils.BitVector) int {
+	switch o.TieBreaking {
+	case FewestRowsTieBreaking:
+		if difference := countTouchedRows(shape, a) - countTouchedRows(shape, b); difference != 0 {
+			return difference
+		}
+	case CentreTieBreaking:
+		if difference := sumCentreDistances(shape, a) - sumCentreDistances(shape, b); difference != 0 {
+			return difference
+		}
+	case NoTieBreaking:
+		return 0
+	}
+
+	return compareLexicographically(shape, a, b)
+}
+
+// Compares the lists of the clicked cells of the solutions in lexicographic order
+func compareLexicographically(shape Shape, a, b utils.BitVector) int {
+	for i := 0; i < shape.CellCount(); i++ {
+		if a.TestBit(i) == b.TestBit(i) {
+			continue
+		}
+
+		// At the first difference the list containing the cell has the lower index in this position,
+		// unless the other list has already ended, as a prefix comes before the longer list
+		if a.TestBit(i) {
+			if hasClickAfter(shape, b, i) {
+				return -1
+			}
+			return 1
+		}
+
+		if hasClickAfter(shape, a, i) {
+			return 1
+		}
+		return -1
+	}
+
+	return 0
+}
+
+func hasClickAfter(shape Shape, solution utils.BitVector, index int) bool {
+	for i := index + 1; i < shape.CellCount(); i++ {
+		if solution.TestBit(i) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func countTouchedRows(shape Shape, solution utils.BitVector) int {
+	count := 0
+	for row := 0; row < shape.RowCount; row++ {
+		for column := 0; column < shape.ColumnCount; column++ {
+			if solution.TestBit(row*shape.ColumnCount + column) {
+				count++
+				break
+			}
+		}
+	}
+
+	return count
+}
+
+// Returns the sum of the squared distances of the clicked cells from the centre,
+// measured in half cells, so that the centre between the cells stays an integer
+func sumCentreDistances(shape Shape, solution utils.BitVector) int {
+	sum := 0
+	for i := 0; i < shape.CellCount(); i++ {
+		if !solution.TestBit(i) {
+			continue
+		}
+
+		rowDistance := 2*(i/shape.ColumnCount) - (shape.RowCount - 1)
+		columnDistance := 2*(i%shape.ColumnCount) - (shape.ColumnCount - 1)
+		sum += rowDistance*rowDistance + columnDistance*columnDistance
+	}
+
+	return sum
+}
@@ -0,0 +1,207 @@
+package solver
+
+import (
+	"context"
+	"server/utils"
+	"testing"
+	"time"
+)
+
+// A 4x4 board with three optimal solutions of 5 clicks, each of them preferred by a different tie-breaking rule
+var tiedBoard = utils.BitVector{0b0110_1011_1101_0000}
+
+var (
+	tiedLexicographicSolution = utils.BitVector{0b0100_1000_0011_0001}
+	tiedFewestRowsSolution    = utils.BitVector{0b1010_1101_0000_0000}
+	tiedCentreSolution        = utils.BitVector{0b0000_0110_0010_1100}
+)
+
+func TestOptimalSolutions(t *testing.T) {
+	testCases := []struct {
+		name              string
+		options           Options
+		expectedSolutions []utils.BitVector
+	}{
+		{
+			name:              "Lexicographic order",
+			options:           Options{TieBreaking: LexicographicTieBreaking},
+			expectedSolutions: []utils.BitVector{tiedLexicographicSolution, tiedCentreSolution, tiedFewestRowsSolution},
+		},
+		{
+			name:              "Fewest rows touched",
+			options:           Options{TieBreaking: FewestRowsTieBreaking},
+			expectedSolutions: []utils.BitVector{tiedFewestRowsSolution, tiedCentreSolution, tiedLexicographicSolution},
+		},
+		{
+			name:              "Closest to the centre",
+			options:           Options{TieBreaking: CentreTieBreaking},
+			expectedSolutions: []utils.BitVector{tiedCentreSolution, tiedLexicographicSolution, tiedFewestRowsSolution},
+		},
+	}
+
+	shape := Shape{RowCount: 4, ColumnCount: 4}
+	enumerator := NewSolutionEnumerator(NewGaussianEliminator())
+	solver := NewBoardSolver(NewGaussianEliminator(), NewFreeVariableFixer(NewBruteForceOptimizer()))
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Arrange
+			_, space := enumerator.SolutionSpace(shape, tiedBoard, testCase.options)
+
+			// Act
+			solutions, err := space.OptimalSolutions(shape, testCase.options)
+			solvability, solution, _ := solver.SolveBoard(context.Background(), shape, tiedBoard, testCase.options)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if len(solutions) != len(testCase.expectedSolutions) {
+				t.Fatalf("Incorrect number of optimal solutions: expected %v, got %v", len(testCase.expectedSolutions), len(solutions))
+			}
+
+			for i, expected := range testCase.expectedSolutions {
+				if !solutions[i].Equal(expected) {
+					t.Errorf("Incorrect optimal solution at %v: expected %b, got %b", i, expected, solutions[i])
+				}
+			}
+
+			if solvability != Solvable || !solution.Equal(testCase.expectedSolutions[0]) {
+				t.Errorf("Incorrect result of SolveBoard: expected %b, got %v %b", testCase.expectedSolutions[0], solvability, solution)
+			}
+		})
+	}
+}
+
+func TestOptimalSolutionsWithoutTieBreaking(t *testing.T) {
+	// Arrange
+	shape := Shape{RowCount: 4, ColumnCount: 4}
+	_, space := NewSolutionEnumerator(NewGaussianEliminator()).SolutionSpace(shape, tiedBoard, Options{})
+
+	// Act
+	solutions, err := space.OptimalSolutions(shape, Options{})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	found := 0
+	for _, solution := range solutions {
+		if solution.Equal(tiedLexicographicSolution) || solution.Equal(tiedFewestRowsSolution) || solution.Equal(tiedCentreSolution) {
+			found++
+		}
+	}
+
+	if len(solutions) != 3 || found != 3 {
+		t.Errorf("Incorrect optimal solutions: %b", solutions)
+	}
+}
+
+func TestOptimalSolutionsWithCosts(t *testing.T) {
+	// Arrange
+	shape := Shape{RowCount: 3, ColumnCount: 3}
+	board := utils.BitVector{0b000_000_000}
+	options := Options{TargetMask: utils.BitVector{0b000_010_000}, Costs: []int{1, 2, 1, 2, 3, 2, 1, 2, 1}, TieBreaking: LexicographicTieBreaking}
+	_, space := NewSolutionEnumerator(NewGaussianEliminator()).SolutionSpace(shape, board, options)
+
+	// Act
+	solutions, err := space.OptimalSolutions(shape, options)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Keeping the center off costs nothing without any clicks
+	if len(solutions) != 1 || !solutions[0].IsZero() {
+		t.Errorf("Incorrect optimal solutions: %b", solutions)
+	}
+}
+
+func TestSolveBoardWithTieBreakingBeforeDeadline(t *testing.T) {
+	// Arrange
+	// Only the first cell has a target, so the other 39 clicks are free, and their 2^39 combinations cannot all be tried
+	shape := Shape{RowCount: 1, ColumnCount: 40}
+	board := utils.NewBitVector(shape.CellCount())
+	board.SetBit(0)
+	targetMask := utils.NewBitVector(shape.CellCount())
+	targetMask.SetBit(0)
+	options := Options{TargetMask: targetMask, TieBreaking: LexicographicTieBreaking}
+
+	solver := NewBoardSolver(NewGaussianEliminator(), NewFreeVariableFixer(NewBruteForceOptimizer()))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	start := time.Now()
+	solvability, solution, optimal := solver.SolveBoard(ctx, shape, board, options)
+
+	// Assert
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("The search was not stopped at the deadline, it took %v", elapsed)
+	}
+
+	if solvability != Solvable || !reachesTarget(shape, board, options, solution) {
+		t.Errorf("Incorrect result: %v %b does not reach the target", solvability, solution)
+	}
+
+	if optimal {
+		t.Error("The solution cannot be proven optimal before the deadline")
+	}
+}
+
+func TestCompareLexicographically(t *testing.T) {
+	testCases := []struct {
+		name     string
+		a        utils.BitVector
+		b        utils.BitVector
+		expected int
+	}{
+		{
+			name:     "Equal solutions",
+			a:        utils.BitVector{0b0110},
+			b:        utils.BitVector{0b0110},
+			expected: 0,
+		},
+		{
+			name:     "Lower first index",
+			a:        utils.BitVector{0b1001},
+			b:        utils.BitVector{0b0110},
+			expected: -1,
+		},
+		{
+			name:     "Higher first index",
+			a:        utils.BitVector{0b0110},
+			b:        utils.BitVector{0b1001},
+			expected: 1,
+		},
+		{
+			name:     "Prefix of the other solution",
+			a:        utils.BitVector{0b0001},
+			b:        utils.BitVector{0b1001},
+			expected: -1,
+		},
+		{
+			name:     "Longer than the other solution",
+			a:        utils.BitVector{0b0011},
+			b:        utils.BitVector{0b0001},
+			expected: 1,
+		},
+	}
+
+	shape := Shape{RowCount: 2, ColumnCount: 2}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Act
+			result := compareLexicographically(shape, testCase.a, testCase.b)
+
+			// Assert
+			if result != testCase.expected {
+				t.Errorf("Incorrect result: expected %v, got %v", testCase.expected, result)
+			}
+		})
+	}
+}
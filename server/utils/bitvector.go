@@ -0,0 +1,114 @@
+package utils
+
+import "math/bits"
+
+const wordSize = 64
+
+// BitVector is an arbitrary-width vector over GF(2), backed by 64-bit words.
+// The modifying operations work in place, so a vector has to be cloned before
+// changing it if the original value is still needed.
+type BitVector []uint64
+
+func NewBitVector(length int) BitVector {
+	return make(BitVector, (length+wordSize-1)/wordSize)
+}
+
+func (v BitVector) TestBit(index int) bool {
+	return v[index/wordSize]&(1<<(index%wordSize)) > 0
+}
+
+func (v BitVector) SetBit(index int) {
+	v[index/wordSize] |= 1 << (index % wordSize)
+}
+
+func (v BitVector) ClearBit(index int) {
+	v[index/wordSize] &^= 1 << (index % wordSize)
+}
+
+func (v BitVector) FlipBit(index int) {
+	v[index/wordSize] ^= 1 << (index % wordSize)
+}
+
+// Xor adds the other vector to this one, the other vector cannot be longer than this one
+func (v BitVector) Xor(other BitVector) {
+	for i, word := range other {
+		v[i] ^= word
+	}
+}
+
+// And keeps only the bits that are set in the other vector too, the missing words of a shorter vector count as zero
+func (v BitVector) And(other BitVector) {
+	for i := range v {
+		if i < len(other) {
+			v[i] &= other[i]
+		} else {
+			v[i] = 0
+		}
+	}
+}
+
+func (v BitVector) OnesCount() (count int) {
+	for _, word := range v {
+		count += bits.OnesCount64(word)
+	}
+
+	return
+}
+
+// TrailingZeros returns the index of the lowest set bit, or the capacity of the vector if it is empty
+func (v BitVector) TrailingZeros() int {
+	for i, word := range v {
+		if word != 0 {
+			return i*wordSize + bits.TrailingZeros64(word)
+		}
+	}
+
+	return len(v) * wordSize
+}
+
+func (v BitVector) IsZero() bool {
+	for _, word := range v {
+		if word != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (v BitVector) Clone() BitVector {
+	clone := make(BitVector, len(v))
+	copy(clone, v)
+
+	return clone
+}
+
+// Equal reports whether the two vectors have the same bits set, regardless of their capacity
+func (v BitVector) Equal(other BitVector) bool {
+	if len(v) < len(other) {
+		v, other = other, v
+	}
+
+	for i, word := range v {
+		otherWord := uint64(0)
+		if i < len(other) {
+			otherWord = other[i]
+		}
+
+		if word != otherWord {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Dot returns the dot product of the two vectors over GF(2), i.e. the parity of their common bits
+func (v BitVector) Dot(other BitVector) bool {
+	count := 0
+	for i := 0; i < len(v) && i < len(other); i++ {
+		count += bits.OnesCount64(v[i] & other[i])
+	}
+
+	return count%2 == 1
+}
@@ -0,0 +1,405 @@
+package utils
+
+import "testing"
+
+func TestNewBitVector(t *testing.T) {
+	testCases := []struct {
+		name              string
+		length            int
+		expectedWordCount int
+	}{
+		{
+			name:              "Empty vector",
+			length:            0,
+			expectedWordCount: 0,
+		},
+		{
+			name:              "Single bit",
+			length:            1,
+			expectedWordCount: 1,
+		},
+		{
+			name:              "Exactly one word",
+			length:            64,
+			expectedWordCount: 1,
+		},
+		{
+			name:              "Just over one word",
+			length:            65,
+			expectedWordCount: 2,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Act
+			result := NewBitVector(testCase.length)
+
+			// Assert
+			if len(result) != testCase.expectedWordCount {
+				t.Errorf("Incorrect word count: expected %v, got %v", testCase.expectedWordCount, len(result))
+			}
+
+			if !result.IsZero() {
+				t.Errorf("Incorrect result: expected empty vector, got %v", result)
+			}
+		})
+	}
+}
+
+func TestBitVectorTestBit(t *testing.T) {
+	testCases := []struct {
+		name           string
+		vector         BitVector
+		index          int
+		expectedResult bool
+	}{
+		{
+			name:           "Empty vector, position 0",
+			vector:         BitVector{0b0, 0b0},
+			index:          0,
+			expectedResult: false,
+		},
+		{
+			name:           "Empty vector, position 100",
+			vector:         BitVector{0b0, 0b0},
+			index:          100,
+			expectedResult: false,
+		},
+		{
+			name:           "Random vector, position 6",
+			vector:         BitVector{0b1111_1111_0001_0011_1100, 0b1},
+			index:          6,
+			expectedResult: false,
+		},
+		{
+			name:           "Random vector, position 17",
+			vector:         BitVector{0b1111_1111_0001_0011_1100, 0b1},
+			index:          17,
+			expectedResult: true,
+		},
+		{
+			name:           "Random vector, position 64",
+			vector:         BitVector{0b1111_1111_0001_0011_1100, 0b1},
+			index:          64,
+			expectedResult: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Act
+			result := testCase.vector.TestBit(testCase.index)
+
+			// Assert
+			if result != testCase.expectedResult {
+				t.Errorf("Incorrect result: expected %v, got %v", testCase.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestBitVectorModifications(t *testing.T) {
+	testCases := []struct {
+		name           string
+		operation      func(BitVector, int)
+		vector         BitVector
+		index          int
+		expectedResult BitVector
+	}{
+		{
+			name:           "Set bit in the first word",
+			operation:      BitVector.SetBit,
+			vector:         BitVector{0b1000, 0b0},
+			index:          1,
+			expectedResult: BitVector{0b1010, 0b0},
+		},
+		{
+			name:           "Set bit that is already set",
+			operation:      BitVector.SetBit,
+			vector:         BitVector{0b1000, 0b0},
+			index:          3,
+			expectedResult: BitVector{0b1000, 0b0},
+		},
+		{
+			name:           "Set bit in the second word",
+			operation:      BitVector.SetBit,
+			vector:         BitVector{0b1000, 0b0},
+			index:          66,
+			expectedResult: BitVector{0b1000, 0b100},
+		},
+		{
+			name:           "Clear bit in the first word",
+			operation:      BitVector.ClearBit,
+			vector:         BitVector{0b1010, 0b1},
+			index:          3,
+			expectedResult: BitVector{0b0010, 0b1},
+		},
+		{
+			name:           "Clear bit that is not set",
+			operation:      BitVector.ClearBit,
+			vector:         BitVector{0b1010, 0b1},
+			index:          2,
+			expectedResult: BitVector{0b1010, 0b1},
+		},
+		{
+			name:           "Clear bit in the second word",
+			operation:      BitVector.ClearBit,
+			vector:         BitVector{0b1010, 0b1},
+			index:          64,
+			expectedResult: BitVector{0b1010, 0b0},
+		},
+		{
+			name:           "Flip unset bit",
+			operation:      BitVector.FlipBit,
+			vector:         BitVector{0b1010, 0b1},
+			index:          0,
+			expectedResult: BitVector{0b1011, 0b1},
+		},
+		{
+			name:           "Flip set bit in the second word",
+			operation:      BitVector.FlipBit,
+			vector:         BitVector{0b1010, 0b1},
+			index:          64,
+			expectedResult: BitVector{0b1010, 0b0},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Act
+			testCase.operation(testCase.vector, testCase.index)
+
+			// Assert
+			if !testCase.vector.Equal(testCase.expectedResult) {
+				t.Errorf("Incorrect result: expected %v, got %v", testCase.expectedResult, testCase.vector)
+			}
+		})
+	}
+}
+
+func TestBitVectorXor(t *testing.T) {
+	// Arrange
+	vector := BitVector{0b1100, 0b1010, 0b1}
+	other := BitVector{0b1010, 0b1010}
+
+	// Act
+	vector.Xor(other)
+
+	// Assert
+	expectedResult := BitVector{0b0110, 0b0000, 0b1}
+	if !vector.Equal(expectedResult) {
+		t.Errorf("Incorrect result: expected %v, got %v", expectedResult, vector)
+	}
+
+	expectedOther := BitVector{0b1010, 0b1010}
+	if !other.Equal(expectedOther) {
+		t.Errorf("The other vector was modified: expected %v, got %v", expectedOther, other)
+	}
+}
+
+func TestBitVectorAnd(t *testing.T) {
+	// Arrange
+	vector := BitVector{0b1100, 0b1010, 0b1}
+	other := BitVector{0b1010, 0b0110}
+
+	// Act
+	vector.And(other)
+
+	// Assert
+	expectedResult := BitVector{0b1000, 0b0010, 0b0}
+	if !vector.Equal(expectedResult) {
+		t.Errorf("Incorrect result: expected %v, got %v", expectedResult, vector)
+	}
+
+	expectedOther := BitVector{0b1010, 0b0110}
+	if !other.Equal(expectedOther) {
+		t.Errorf("The other vector was modified: expected %v, got %v", expectedOther, other)
+	}
+}
+
+func TestBitVectorCounting(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		vector                BitVector
+		expectedOnesCount     int
+		expectedTrailingZeros int
+		expectedIsZero        bool
+	}{
+		{
+			name:                  "Empty vector without words",
+			vector:                BitVector{},
+			expectedOnesCount:     0,
+			expectedTrailingZeros: 0,
+			expectedIsZero:        true,
+		},
+		{
+			name:                  "Empty vector",
+			vector:                BitVector{0b0, 0b0},
+			expectedOnesCount:     0,
+			expectedTrailingZeros: 128,
+			expectedIsZero:        true,
+		},
+		{
+			name:                  "Bits in the first word",
+			vector:                BitVector{0b1011_0100, 0b0},
+			expectedOnesCount:     4,
+			expectedTrailingZeros: 2,
+			expectedIsZero:        false,
+		},
+		{
+			name:                  "Bits in both words",
+			vector:                BitVector{0b1000_0000, 0b111},
+			expectedOnesCount:     4,
+			expectedTrailingZeros: 7,
+			expectedIsZero:        false,
+		},
+		{
+			name:                  "Bits only in the second word",
+			vector:                BitVector{0b0, 0b1010},
+			expectedOnesCount:     2,
+			expectedTrailingZeros: 65,
+			expectedIsZero:        false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Act
+			onesCount := testCase.vector.OnesCount()
+			trailingZeros := testCase.vector.TrailingZeros()
+			isZero := testCase.vector.IsZero()
+
+			// Assert
+			if onesCount != testCase.expectedOnesCount {
+				t.Errorf("Incorrect result for OnesCount: expected %v, got %v", testCase.expectedOnesCount, onesCount)
+			}
+
+			if trailingZeros != testCase.expectedTrailingZeros {
+				t.Errorf("Incorrect result for TrailingZeros: expected %v, got %v", testCase.expectedTrailingZeros, trailingZeros)
+			}
+
+			if isZero != testCase.expectedIsZero {
+				t.Errorf("Incorrect result for IsZero: expected %v, got %v", testCase.expectedIsZero, isZero)
+			}
+		})
+	}
+}
+
+func TestBitVectorClone(t *testing.T) {
+	// Arrange
+	vector := BitVector{0b1010, 0b1}
+
+	// Act
+	clone := vector.Clone()
+	clone.SetBit(0)
+
+	// Assert
+	expectedVector := BitVector{0b1010, 0b1}
+	if !vector.Equal(expectedVector) {
+		t.Errorf("The original vector was modified: expected %v, got %v", expectedVector, vector)
+	}
+
+	expectedClone := BitVector{0b1011, 0b1}
+	if !clone.Equal(expectedClone) {
+		t.Errorf("Incorrect result: expected %v, got %v", expectedClone, clone)
+	}
+}
+
+func TestBitVectorEqual(t *testing.T) {
+	testCases := []struct {
+		name           string
+		vector         BitVector
+		other          BitVector
+		expectedResult bool
+	}{
+		{
+			name:           "Same vectors",
+			vector:         BitVector{0b1010, 0b1},
+			other:          BitVector{0b1010, 0b1},
+			expectedResult: true,
+		},
+		{
+			name:           "Different vectors",
+			vector:         BitVector{0b1010, 0b1},
+			other:          BitVector{0b1010, 0b0},
+			expectedResult: false,
+		},
+		{
+			name:           "Same bits with different capacity",
+			vector:         BitVector{0b1010},
+			other:          BitVector{0b1010, 0b0},
+			expectedResult: true,
+		},
+		{
+			name:           "Different bits with different capacity",
+			vector:         BitVector{0b1010, 0b1},
+			other:          BitVector{0b1010},
+			expectedResult: false,
+		},
+		{
+			name:           "Empty vectors",
+			vector:         nil,
+			other:          BitVector{0b0},
+			expectedResult: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Act
+			result := testCase.vector.Equal(testCase.other)
+
+			// Assert
+			if result != testCase.expectedResult {
+				t.Errorf("Incorrect result: expected %v, got %v", testCase.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestBitVectorDot(t *testing.T) {
+	testCases := []struct {
+		name           string
+		vector         BitVector
+		other          BitVector
+		expectedResult bool
+	}{
+		{
+			name:           "No common bits",
+			vector:         BitVector{0b1010, 0b1},
+			other:          BitVector{0b0101, 0b0},
+			expectedResult: false,
+		},
+		{
+			name:           "Odd number of common bits",
+			vector:         BitVector{0b1010, 0b1},
+			other:          BitVector{0b1110, 0b1},
+			expectedResult: true,
+		},
+		{
+			name:           "Even number of common bits",
+			vector:         BitVector{0b1010, 0b1},
+			other:          BitVector{0b0010, 0b1},
+			expectedResult: false,
+		},
+		{
+			name:           "Different capacity",
+			vector:         BitVector{0b1010, 0b1},
+			other:          BitVector{0b1000},
+			expectedResult: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Act
+			result := testCase.vector.Dot(testCase.other)
+
+			// Assert
+			if result != testCase.expectedResult {
+				t.Errorf("Incorrect result: expected %v, got %v", testCase.expectedResult, result)
+			}
+		})
+	}
+}